@@ -9,6 +9,92 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for RegisteredJobScheduleType.
+const (
+	Cron  RegisteredJobScheduleType = "cron"
+	Rrule RegisteredJobScheduleType = "rrule"
+)
+
+// Defines values for RegisteredJobSource.
+const (
+	Api  RegisteredJobSource = "api"
+	Code RegisteredJobSource = "code"
+)
+
+// Defines values for WorkflowFailurePolicy.
+const (
+	Continue WorkflowFailurePolicy = "continue"
+	Stop     WorkflowFailurePolicy = "stop"
+)
+
+// Defines values for WorkflowRunStatus.
+const (
+	WorkflowRunStatusCompleted WorkflowRunStatus = "completed"
+	WorkflowRunStatusFailed    WorkflowRunStatus = "failed"
+	WorkflowRunStatusRunning   WorkflowRunStatus = "running"
+)
+
+// Defines values for WorkflowRunNodeStatus.
+const (
+	WorkflowRunNodeStatusCompleted WorkflowRunNodeStatus = "completed"
+	WorkflowRunNodeStatusFailed    WorkflowRunNodeStatus = "failed"
+	WorkflowRunNodeStatusPending   WorkflowRunNodeStatus = "pending"
+	WorkflowRunNodeStatusRunning   WorkflowRunNodeStatus = "running"
+	WorkflowRunNodeStatusSkipped   WorkflowRunNodeStatus = "skipped"
+)
+
+// Defines values for WorkflowRunStateStatus.
+const (
+	Completed WorkflowRunStateStatus = "completed"
+	Failed    WorkflowRunStateStatus = "failed"
+	Running   WorkflowRunStateStatus = "running"
+)
+
+// BlackoutWindow defines model for BlackoutWindow.
+type BlackoutWindow struct {
+	// CreatedAt When the blackout window was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// EndTime When the blackout window ends, after its start
+	EndTime time.Time `json:"end_time"`
+
+	// Id Unique identifier for the blackout window
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Name of the blackout window, e.g. the maintenance it covers
+	Name string `json:"name"`
+
+	// StartTime When the blackout window starts
+	StartTime time.Time `json:"start_time"`
+
+	// TenantId Tenant ID the blackout window belongs to
+	TenantId string `json:"tenant_id"`
+
+	// UpdatedAt When the blackout window was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Holiday defines model for Holiday.
+type Holiday struct {
+	// CreatedAt When the holiday was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Date Day of the holiday, covered whole in the time zone of each job
+	Date openapi_types.Date `json:"date"`
+
+	// Id Unique identifier for the holiday
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Name of the holiday
+	Name string `json:"name"`
+
+	// TenantId Tenant ID the holiday belongs to
+	TenantId string `json:"tenant_id"`
+
+	// UpdatedAt When the holiday was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Job defines model for Job.
 type Job struct {
 	Id                openapi_types.UUID `json:"id"`
@@ -22,19 +108,52 @@ type Job struct {
 
 // JobAuditLog defines model for JobAuditLog.
 type JobAuditLog struct {
-	AppId         string             `json:"app_id"`
-	CreatedAt     time.Time          `json:"createdAt"`
-	EndTime       *time.Time         `json:"end_time,omitempty"`
-	Error         *string            `json:"error,omitempty"`
-	Id            openapi_types.UUID `json:"id"`
-	JobName       string             `json:"job_name"`
-	Output        *string            `json:"output,omitempty"`
-	RequestId     string             `json:"request_id"`
-	ScheduledTime time.Time          `json:"scheduled_time"`
-	StartTime     *time.Time         `json:"start_time,omitempty"`
-	Status        string             `json:"status"`
-	TenantID      string             `json:"tenantID"`
-	UpdatedAt     *time.Time         `json:"updatedAt,omitempty"`
+	AppId     string    `json:"app_id"`
+	Attempt   *int      `json:"attempt,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Delay Seconds between the scheduled time and the start time
+	Delay   *float64           `json:"delay,omitempty"`
+	EndTime *time.Time         `json:"end_time,omitempty"`
+	Error   *string            `json:"error,omitempty"`
+	Id      openapi_types.UUID `json:"id"`
+
+	// JitterMs Random delay in milliseconds applied before the run started
+	JitterMs      *int                    `json:"jitter_ms,omitempty"`
+	JobName       string                  `json:"job_name"`
+	Output        *string                 `json:"output,omitempty"`
+	ParentId      *openapi_types.UUID     `json:"parent_id,omitempty"`
+	RequestId     string                  `json:"request_id"`
+	Result        *map[string]interface{} `json:"result,omitempty"`
+	ScheduledTime time.Time               `json:"scheduled_time"`
+	StartTime     *time.Time              `json:"start_time,omitempty"`
+	Status        string                  `json:"status"`
+	TenantID      string                  `json:"tenantID"`
+	UpdatedAt     *time.Time              `json:"updatedAt,omitempty"`
+
+	// WorkflowRunId ID of the workflow run the run belongs to
+	WorkflowRunId *openapi_types.UUID `json:"workflow_run_id,omitempty"`
+}
+
+// NewBlackoutWindow defines model for NewBlackoutWindow.
+type NewBlackoutWindow struct {
+	// EndTime When the blackout window ends, after its start
+	EndTime time.Time `json:"end_time"`
+
+	// Name Name of the blackout window, e.g. the maintenance it covers
+	Name string `json:"name"`
+
+	// StartTime When the blackout window starts
+	StartTime time.Time `json:"start_time"`
+}
+
+// NewHoliday defines model for NewHoliday.
+type NewHoliday struct {
+	// Date Day of the holiday, covered whole in the time zone of each job
+	Date openapi_types.Date `json:"date"`
+
+	// Name Name of the holiday
+	Name string `json:"name"`
 }
 
 // NewJob defines model for NewJob.
@@ -47,6 +166,24 @@ type NewJob struct {
 	Status            string     `json:"status"`
 }
 
+// NewRegisteredJob defines model for NewRegisteredJob.
+type NewRegisteredJob struct {
+	// IsEnabled Whether the job is enabled
+	IsEnabled *bool `json:"is_enabled,omitempty"`
+
+	// JobName Name of the job, unique for the tenant
+	JobName string `json:"job_name"`
+
+	// JobType Type of the job, whose factory builds the job on every instance
+	JobType string `json:"job_type"`
+
+	// Payload Configuration of the job, passed to the factory and delivered to the job at run time
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// Schedule Schedule of the job, a cron expression or an RFC 5545 recurrence rule
+	Schedule string `json:"schedule"`
+}
+
 // RegisteredJob defines model for RegisteredJob.
 type RegisteredJob struct {
 	// CreatedAt When the job was first registered
@@ -70,15 +207,189 @@ type RegisteredJob struct {
 	// JobName Name of the job
 	JobName string `json:"job_name"`
 
+	// JobType Type of the job defined through the API, not set for jobs registered in code
+	JobType *string `json:"job_type,omitempty"`
+
 	// LastRegisteredAt When the job was last registered
 	LastRegisteredAt time.Time `json:"last_registered_at"`
 
-	// Schedule Cron schedule expression
+	// NextRunTimes Next run times of the effective schedule
+	NextRunTimes *[]time.Time `json:"next_run_times,omitempty"`
+
+	// Payload Configuration of the job set through the API, delivered to the job at run time
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// Schedule Schedule defined in code, a cron expression or an RFC 5545 recurrence rule
 	Schedule string `json:"schedule"`
 
+	// ScheduleOverride Cron schedule expression set through the API, used instead of schedule
+	ScheduleOverride *string `json:"schedule_override,omitempty"`
+
+	// ScheduleType Type of the schedule defined in code, a cron expression or an RFC 5545 recurrence rule
+	ScheduleType RegisteredJobScheduleType `json:"schedule_type"`
+
+	// Source Whether the job is registered in code or defined through the API
+	Source RegisteredJobSource `json:"source"`
+
 	// TenantId Tenant ID the job belongs to
 	TenantId string `json:"tenant_id"`
 
+	// TimeZone Time zone the schedule is evaluated in (IANA name), the time zone of the server when not set
+	TimeZone *string `json:"time_zone,omitempty"`
+
 	// UpdatedAt When the job was last updated
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// RegisteredJobScheduleType Type of the schedule defined in code, a cron expression or an RFC 5545 recurrence rule
+type RegisteredJobScheduleType string
+
+// RegisteredJobSource Whether the job is registered in code or defined through the API
+type RegisteredJobSource string
+
+// RunRequest defines model for RunRequest.
+type RunRequest struct {
+	// RequestId ID of the run request, also recorded as request ID in the audit log
+	RequestId openapi_types.UUID `json:"request_id"`
+}
+
+// ScheduleValidation defines model for ScheduleValidation.
+type ScheduleValidation struct {
+	// Error Parse error, when not valid
+	Error *string `json:"error,omitempty"`
+
+	// Field Name of the invalid field of the expression, when the error is about a single field
+	Field *string `json:"field,omitempty"`
+
+	// NextRunTimes Next run times of the schedule, when valid
+	NextRunTimes *[]time.Time `json:"next_run_times,omitempty"`
+
+	// Position Offset of the invalid field in the expression, when the error is about a single field
+	Position *int `json:"position,omitempty"`
+
+	// Valid Whether the expression and the time zone are valid
+	Valid bool `json:"valid"`
+}
+
+// ScheduleValidationRequest defines model for ScheduleValidationRequest.
+type ScheduleValidationRequest struct {
+	// Count Number of next run times to return
+	Count *int `json:"count,omitempty"`
+
+	// Expression Cron schedule expression, with 6 fields starting with seconds, 5 fields, a descriptor such as @daily, an @every duration or an RFC 5545 recurrence rule
+	Expression string `json:"expression"`
+
+	// TimeZone Time zone the schedule is evaluated in (IANA name), the time zone of the server when not set
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// Workflow defines model for Workflow.
+type Workflow struct {
+	// CreatedAt When the workflow was first registered
+	CreatedAt time.Time `json:"created_at"`
+
+	// Edges Edges of the DAG of the workflow
+	Edges []WorkflowEdge `json:"edges"`
+
+	// FailurePolicy Whether a job that fails or does not run skips the jobs not triggered yet (stop) or not (continue)
+	FailurePolicy WorkflowFailurePolicy `json:"failure_policy"`
+
+	// Id Unique identifier for the workflow
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Name of the workflow, registered in code
+	Name string `json:"name"`
+
+	// TenantId Tenant ID the workflow belongs to
+	TenantId string `json:"tenant_id"`
+
+	// UpdatedAt When the workflow was last registered
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkflowFailurePolicy Whether a job that fails or does not run skips the jobs not triggered yet (stop) or not (continue)
+type WorkflowFailurePolicy string
+
+// WorkflowEdge defines model for WorkflowEdge.
+type WorkflowEdge struct {
+	// DownstreamJob Name of the job triggered once all its upstream jobs finished
+	DownstreamJob string `json:"downstream_job"`
+
+	// UpstreamJob Name of the job running first
+	UpstreamJob string `json:"upstream_job"`
+}
+
+// WorkflowRun defines model for WorkflowRun.
+type WorkflowRun struct {
+	// EndedAt When the last job of the run finished
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+
+	// Id Unique identifier for the workflow run, also set as workflow_run_id on the audit logs of its jobs
+	Id openapi_types.UUID `json:"id"`
+
+	// StartedAt When the root job of the workflow finished, starting the run
+	StartedAt time.Time `json:"started_at"`
+
+	// Status Status of the run, failed when any of its jobs did not complete
+	Status WorkflowRunStatus `json:"status"`
+
+	// TenantId Tenant ID the workflow run belongs to
+	TenantId string `json:"tenant_id"`
+
+	// WorkflowId ID of the workflow
+	WorkflowId openapi_types.UUID `json:"workflow_id"`
+}
+
+// WorkflowRunStatus Status of the run, failed when any of its jobs did not complete
+type WorkflowRunStatus string
+
+// WorkflowRunNode defines model for WorkflowRunNode.
+type WorkflowRunNode struct {
+	// AuditLogId ID of the audit log of the run of the job
+	AuditLogId *openapi_types.UUID `json:"audit_log_id,omitempty"`
+
+	// JobName Name of the job
+	JobName string `json:"job_name"`
+
+	// Status Status of the job in the workflow run
+	Status WorkflowRunNodeStatus `json:"status"`
+
+	// UpdatedAt When the status of the job last changed
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkflowRunNodeStatus Status of the job in the workflow run
+type WorkflowRunNodeStatus string
+
+// WorkflowRunState defines model for WorkflowRunState.
+type WorkflowRunState struct {
+	// Edges Edges of the DAG of the workflow
+	Edges []WorkflowEdge `json:"edges"`
+
+	// EndedAt When the last job of the run finished
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+
+	// Id Unique identifier for the workflow run, also set as workflow_run_id on the audit logs of its jobs
+	Id openapi_types.UUID `json:"id"`
+
+	// Nodes State of each job of the workflow run
+	Nodes []WorkflowRunNode `json:"nodes"`
+
+	// StartedAt When the root job of the workflow finished, starting the run
+	StartedAt time.Time `json:"started_at"`
+
+	// Status Status of the run, failed when any of its jobs did not complete
+	Status WorkflowRunStateStatus `json:"status"`
+
+	// TenantId Tenant ID the workflow run belongs to
+	TenantId string `json:"tenant_id"`
+
+	// WorkflowId ID of the workflow
+	WorkflowId openapi_types.UUID `json:"workflow_id"`
+
+	// WorkflowName Name of the workflow
+	WorkflowName string `json:"workflow_name"`
+}
+
+// WorkflowRunStateStatus Status of the run, failed when any of its jobs did not complete
+type WorkflowRunStateStatus string
@@ -6,6 +6,7 @@ package cron
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oapi-codegen/runtime"
@@ -56,6 +57,48 @@ const (
 	GetJobAuditLogsParamsOrderDesc GetJobAuditLogsParamsOrder = "desc"
 )
 
+// CreateBlackoutWindowJSONBody defines parameters for CreateBlackoutWindow.
+type CreateBlackoutWindowJSONBody struct {
+	// EndTime When the blackout window ends, after its start
+	EndTime time.Time `json:"end_time"`
+
+	// Name Name of the blackout window, e.g. the maintenance it covers
+	Name string `json:"name"`
+
+	// StartTime When the blackout window starts
+	StartTime time.Time `json:"start_time"`
+}
+
+// UpdateBlackoutWindowJSONBody defines parameters for UpdateBlackoutWindow.
+type UpdateBlackoutWindowJSONBody struct {
+	// EndTime When the blackout window ends, after its start
+	EndTime time.Time `json:"end_time"`
+
+	// Name Name of the blackout window, e.g. the maintenance it covers
+	Name string `json:"name"`
+
+	// StartTime When the blackout window starts
+	StartTime time.Time `json:"start_time"`
+}
+
+// CreateHolidayJSONBody defines parameters for CreateHoliday.
+type CreateHolidayJSONBody struct {
+	// Date Day of the holiday, covered whole in the time zone of each job
+	Date openapi_types.Date `json:"date"`
+
+	// Name Name of the holiday
+	Name string `json:"name"`
+}
+
+// UpdateHolidayJSONBody defines parameters for UpdateHoliday.
+type UpdateHolidayJSONBody struct {
+	// Date Day of the holiday, covered whole in the time zone of each job
+	Date openapi_types.Date `json:"date"`
+
+	// Name Name of the holiday
+	Name string `json:"name"`
+}
+
 // ListJobAuditLogsParams defines parameters for ListJobAuditLogs.
 type ListJobAuditLogsParams struct {
 	// Page page number
@@ -140,10 +183,40 @@ type ListRegisteredJobsParamsSortBy string
 // ListRegisteredJobsParamsOrder defines parameters for ListRegisteredJobs.
 type ListRegisteredJobsParamsOrder string
 
+// CreateRegisteredJobJSONBody defines parameters for CreateRegisteredJob.
+type CreateRegisteredJobJSONBody struct {
+	// IsEnabled Whether the job is enabled
+	IsEnabled *bool `json:"is_enabled,omitempty"`
+
+	// JobName Name of the job, unique for the tenant
+	JobName string `json:"job_name"`
+
+	// JobType Type of the job, whose factory builds the job on every instance
+	JobType string `json:"job_type"`
+
+	// Payload Configuration of the job, passed to the factory and delivered to the job at run time
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// Schedule Schedule of the job, a cron expression or an RFC 5545 recurrence rule
+	Schedule string `json:"schedule"`
+}
+
 // UpdateRegisteredJobJSONBody defines parameters for UpdateRegisteredJob.
 type UpdateRegisteredJobJSONBody struct {
 	// IsEnabled Whether the job is enabled
 	IsEnabled *bool `json:"is_enabled,omitempty"`
+
+	// Payload Configuration of the job, replacing its current payload
+	Payload *map[string]interface{} `json:"payload,omitempty"`
+
+	// ResetPayload Remove the payload of the job
+	ResetPayload *bool `json:"reset_payload,omitempty"`
+
+	// ResetSchedule Remove the schedule override and revert to the schedule defined in code
+	ResetSchedule *bool `json:"reset_schedule,omitempty"`
+
+	// ScheduleOverride Cron schedule expression replacing the schedule defined in code
+	ScheduleOverride *string `json:"schedule_override,omitempty"`
 }
 
 // GetJobAuditLogsParams defines parameters for GetJobAuditLogs.
@@ -167,12 +240,81 @@ type GetJobAuditLogsParams struct {
 // GetJobAuditLogsParamsOrder defines parameters for GetJobAuditLogs.
 type GetJobAuditLogsParamsOrder string
 
+// ValidateScheduleJSONBody defines parameters for ValidateSchedule.
+type ValidateScheduleJSONBody struct {
+	// Count Number of next run times to return
+	Count *int `json:"count,omitempty"`
+
+	// Expression Cron schedule expression, with 6 fields starting with seconds, 5 fields, a descriptor such as @daily, an @every duration or an RFC 5545 recurrence rule
+	Expression string `json:"expression"`
+
+	// TimeZone Time zone the schedule is evaluated in (IANA name), the time zone of the server when not set
+	TimeZone *string `json:"time_zone,omitempty"`
+}
+
+// ListWorkflowRunsParams defines parameters for ListWorkflowRuns.
+type ListWorkflowRunsParams struct {
+	// Page page number
+	Page *int32 `form:"page,omitempty" json:"page,omitempty"`
+
+	// PageSize maximum number of results to return
+	PageSize *int32 `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+}
+
+// CreateBlackoutWindowJSONRequestBody defines body for CreateBlackoutWindow for application/json ContentType.
+type CreateBlackoutWindowJSONRequestBody CreateBlackoutWindowJSONBody
+
+// UpdateBlackoutWindowJSONRequestBody defines body for UpdateBlackoutWindow for application/json ContentType.
+type UpdateBlackoutWindowJSONRequestBody UpdateBlackoutWindowJSONBody
+
+// CreateHolidayJSONRequestBody defines body for CreateHoliday for application/json ContentType.
+type CreateHolidayJSONRequestBody CreateHolidayJSONBody
+
+// UpdateHolidayJSONRequestBody defines body for UpdateHoliday for application/json ContentType.
+type UpdateHolidayJSONRequestBody UpdateHolidayJSONBody
+
+// CreateRegisteredJobJSONRequestBody defines body for CreateRegisteredJob for application/json ContentType.
+type CreateRegisteredJobJSONRequestBody CreateRegisteredJobJSONBody
+
 // UpdateRegisteredJobJSONRequestBody defines body for UpdateRegisteredJob for application/json ContentType.
 type UpdateRegisteredJobJSONRequestBody UpdateRegisteredJobJSONBody
 
+// ValidateScheduleJSONRequestBody defines body for ValidateSchedule for application/json ContentType.
+type ValidateScheduleJSONRequestBody ValidateScheduleJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /api/v1/cron/blackout-windows)
+	ListBlackoutWindows(c *gin.Context)
+
+	// (POST /api/v1/cron/blackout-windows)
+	CreateBlackoutWindow(c *gin.Context)
+
+	// (DELETE /api/v1/cron/blackout-windows/{id})
+	DeleteBlackoutWindow(c *gin.Context, id openapi_types.UUID)
+
+	// (GET /api/v1/cron/blackout-windows/{id})
+	GetBlackoutWindow(c *gin.Context, id openapi_types.UUID)
+
+	// (PUT /api/v1/cron/blackout-windows/{id})
+	UpdateBlackoutWindow(c *gin.Context, id openapi_types.UUID)
+
+	// (GET /api/v1/cron/holidays)
+	ListHolidays(c *gin.Context)
+
+	// (POST /api/v1/cron/holidays)
+	CreateHoliday(c *gin.Context)
+
+	// (DELETE /api/v1/cron/holidays/{id})
+	DeleteHoliday(c *gin.Context, id openapi_types.UUID)
+
+	// (GET /api/v1/cron/holidays/{id})
+	GetHoliday(c *gin.Context, id openapi_types.UUID)
+
+	// (PUT /api/v1/cron/holidays/{id})
+	UpdateHoliday(c *gin.Context, id openapi_types.UUID)
+
 	// (GET /api/v1/cron/job-audit-logs)
 	ListJobAuditLogs(c *gin.Context, params ListJobAuditLogsParams)
 
@@ -200,6 +342,12 @@ type ServerInterface interface {
 	// (GET /api/v1/cron/registered-jobs)
 	ListRegisteredJobs(c *gin.Context, params ListRegisteredJobsParams)
 
+	// (POST /api/v1/cron/registered-jobs)
+	CreateRegisteredJob(c *gin.Context)
+
+	// (DELETE /api/v1/cron/registered-jobs/{id})
+	DeleteRegisteredJob(c *gin.Context, id openapi_types.UUID)
+
 	// (GET /api/v1/cron/registered-jobs/{id})
 	GetRegisteredJob(c *gin.Context, id openapi_types.UUID)
 
@@ -209,11 +357,26 @@ type ServerInterface interface {
 	// (GET /api/v1/cron/registered-jobs/{id}/audit-logs)
 	GetJobAuditLogs(c *gin.Context, id openapi_types.UUID, params GetJobAuditLogsParams)
 
+	// (POST /api/v1/cron/registered-jobs/{id}/run)
+	RunRegisteredJob(c *gin.Context, id openapi_types.UUID)
+
+	// (POST /api/v1/cron/schedules/validate)
+	ValidateSchedule(c *gin.Context)
+
 	// (POST /api/v1/cron/seed/reference)
 	SeedReferenceData(c *gin.Context)
 
 	// (POST /api/v1/cron/seed/sample)
 	SeedSampleData(c *gin.Context)
+
+	// (GET /api/v1/cron/workflow-runs/{id})
+	GetWorkflowRun(c *gin.Context, id openapi_types.UUID)
+
+	// (GET /api/v1/cron/workflows)
+	ListWorkflows(c *gin.Context)
+
+	// (GET /api/v1/cron/workflows/{id}/runs)
+	ListWorkflowRuns(c *gin.Context, id openapi_types.UUID, params ListWorkflowRunsParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -225,6 +388,202 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// ListBlackoutWindows operation middleware
+func (siw *ServerInterfaceWrapper) ListBlackoutWindows(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListBlackoutWindows(c)
+}
+
+// CreateBlackoutWindow operation middleware
+func (siw *ServerInterfaceWrapper) CreateBlackoutWindow(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateBlackoutWindow(c)
+}
+
+// DeleteBlackoutWindow operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBlackoutWindow(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteBlackoutWindow(c, id)
+}
+
+// GetBlackoutWindow operation middleware
+func (siw *ServerInterfaceWrapper) GetBlackoutWindow(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetBlackoutWindow(c, id)
+}
+
+// UpdateBlackoutWindow operation middleware
+func (siw *ServerInterfaceWrapper) UpdateBlackoutWindow(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateBlackoutWindow(c, id)
+}
+
+// ListHolidays operation middleware
+func (siw *ServerInterfaceWrapper) ListHolidays(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListHolidays(c)
+}
+
+// CreateHoliday operation middleware
+func (siw *ServerInterfaceWrapper) CreateHoliday(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateHoliday(c)
+}
+
+// DeleteHoliday operation middleware
+func (siw *ServerInterfaceWrapper) DeleteHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteHoliday(c, id)
+}
+
+// GetHoliday operation middleware
+func (siw *ServerInterfaceWrapper) GetHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetHoliday(c, id)
+}
+
+// UpdateHoliday operation middleware
+func (siw *ServerInterfaceWrapper) UpdateHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateHoliday(c, id)
+}
+
 // ListJobAuditLogs operation middleware
 func (siw *ServerInterfaceWrapper) ListJobAuditLogs(c *gin.Context) {
 
@@ -556,6 +915,43 @@ func (siw *ServerInterfaceWrapper) ListRegisteredJobs(c *gin.Context) {
 	siw.Handler.ListRegisteredJobs(c, params)
 }
 
+// CreateRegisteredJob operation middleware
+func (siw *ServerInterfaceWrapper) CreateRegisteredJob(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateRegisteredJob(c)
+}
+
+// DeleteRegisteredJob operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRegisteredJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteRegisteredJob(c, id)
+}
+
 // GetRegisteredJob operation middleware
 func (siw *ServerInterfaceWrapper) GetRegisteredJob(c *gin.Context) {
 
@@ -671,6 +1067,43 @@ func (siw *ServerInterfaceWrapper) GetJobAuditLogs(c *gin.Context) {
 	siw.Handler.GetJobAuditLogs(c, id, params)
 }
 
+// RunRegisteredJob operation middleware
+func (siw *ServerInterfaceWrapper) RunRegisteredJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RunRegisteredJob(c, id)
+}
+
+// ValidateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) ValidateSchedule(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ValidateSchedule(c)
+}
+
 // SeedReferenceData operation middleware
 func (siw *ServerInterfaceWrapper) SeedReferenceData(c *gin.Context) {
 
@@ -697,6 +1130,86 @@ func (siw *ServerInterfaceWrapper) SeedSampleData(c *gin.Context) {
 	siw.Handler.SeedSampleData(c)
 }
 
+// GetWorkflowRun operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkflowRun(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWorkflowRun(c, id)
+}
+
+// ListWorkflows operation middleware
+func (siw *ServerInterfaceWrapper) ListWorkflows(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWorkflows(c)
+}
+
+// ListWorkflowRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListWorkflowRuns(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWorkflowRunsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter page: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", c.Request.URL.Query(), &params.PageSize)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageSize: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWorkflowRuns(c, id, params)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -724,6 +1237,16 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/api/v1/cron/blackout-windows", wrapper.ListBlackoutWindows)
+	router.POST(options.BaseURL+"/api/v1/cron/blackout-windows", wrapper.CreateBlackoutWindow)
+	router.DELETE(options.BaseURL+"/api/v1/cron/blackout-windows/:id", wrapper.DeleteBlackoutWindow)
+	router.GET(options.BaseURL+"/api/v1/cron/blackout-windows/:id", wrapper.GetBlackoutWindow)
+	router.PUT(options.BaseURL+"/api/v1/cron/blackout-windows/:id", wrapper.UpdateBlackoutWindow)
+	router.GET(options.BaseURL+"/api/v1/cron/holidays", wrapper.ListHolidays)
+	router.POST(options.BaseURL+"/api/v1/cron/holidays", wrapper.CreateHoliday)
+	router.DELETE(options.BaseURL+"/api/v1/cron/holidays/:id", wrapper.DeleteHoliday)
+	router.GET(options.BaseURL+"/api/v1/cron/holidays/:id", wrapper.GetHoliday)
+	router.PUT(options.BaseURL+"/api/v1/cron/holidays/:id", wrapper.UpdateHoliday)
 	router.GET(options.BaseURL+"/api/v1/cron/job-audit-logs", wrapper.ListJobAuditLogs)
 	router.DELETE(options.BaseURL+"/api/v1/cron/job-audit-logs/:id", wrapper.DeleteJobAuditLog)
 	router.GET(options.BaseURL+"/api/v1/cron/job-audit-logs/:id", wrapper.GetJobAuditLogByID)
@@ -733,9 +1256,16 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.POST(options.BaseURL+"/api/v1/cron/migrate/down", wrapper.MigrateDown)
 	router.POST(options.BaseURL+"/api/v1/cron/migrate/up", wrapper.MigrateUp)
 	router.GET(options.BaseURL+"/api/v1/cron/registered-jobs", wrapper.ListRegisteredJobs)
+	router.POST(options.BaseURL+"/api/v1/cron/registered-jobs", wrapper.CreateRegisteredJob)
+	router.DELETE(options.BaseURL+"/api/v1/cron/registered-jobs/:id", wrapper.DeleteRegisteredJob)
 	router.GET(options.BaseURL+"/api/v1/cron/registered-jobs/:id", wrapper.GetRegisteredJob)
 	router.PATCH(options.BaseURL+"/api/v1/cron/registered-jobs/:id", wrapper.UpdateRegisteredJob)
 	router.GET(options.BaseURL+"/api/v1/cron/registered-jobs/:id/audit-logs", wrapper.GetJobAuditLogs)
+	router.POST(options.BaseURL+"/api/v1/cron/registered-jobs/:id/run", wrapper.RunRegisteredJob)
+	router.POST(options.BaseURL+"/api/v1/cron/schedules/validate", wrapper.ValidateSchedule)
 	router.POST(options.BaseURL+"/api/v1/cron/seed/reference", wrapper.SeedReferenceData)
 	router.POST(options.BaseURL+"/api/v1/cron/seed/sample", wrapper.SeedSampleData)
+	router.GET(options.BaseURL+"/api/v1/cron/workflow-runs/:id", wrapper.GetWorkflowRun)
+	router.GET(options.BaseURL+"/api/v1/cron/workflows", wrapper.ListWorkflows)
+	router.GET(options.BaseURL+"/api/v1/cron/workflows/:id/runs", wrapper.ListWorkflowRuns)
 }
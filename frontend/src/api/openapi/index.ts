@@ -7,9 +7,22 @@ export { CancelablePromise, CancelError } from './core/CancelablePromise';
 export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
+export type { BlackoutWindow } from './models/BlackoutWindow';
+export type { Holiday } from './models/Holiday';
 export type { Job } from './models/Job';
 export type { JobAuditLog } from './models/JobAuditLog';
+export type { NewBlackoutWindow } from './models/NewBlackoutWindow';
+export type { NewHoliday } from './models/NewHoliday';
 export type { NewJob } from './models/NewJob';
-export type { RegisteredJob } from './models/RegisteredJob';
+export type { NewRegisteredJob } from './models/NewRegisteredJob';
+export { RegisteredJob } from './models/RegisteredJob';
+export type { RunRequest } from './models/RunRequest';
+export type { ScheduleValidation } from './models/ScheduleValidation';
+export type { ScheduleValidationRequest } from './models/ScheduleValidationRequest';
+export { Workflow } from './models/Workflow';
+export type { WorkflowEdge } from './models/WorkflowEdge';
+export { WorkflowRun } from './models/WorkflowRun';
+export { WorkflowRunNode } from './models/WorkflowRunNode';
+export type { WorkflowRunState } from './models/WorkflowRunState';
 
 export { DefaultService } from './services/DefaultService';
@@ -0,0 +1,24 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { NewBlackoutWindow } from './NewBlackoutWindow';
+export type BlackoutWindow = (NewBlackoutWindow & {
+        /**
+         * Unique identifier for the blackout window
+         */
+        id: string;
+        /**
+         * Tenant ID the blackout window belongs to
+         */
+        tenant_id: string;
+        /**
+         * When the blackout window was created
+         */
+        created_at: string;
+        /**
+         * When the blackout window was last updated
+         */
+        updated_at: string;
+});
+
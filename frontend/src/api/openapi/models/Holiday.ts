@@ -0,0 +1,24 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { NewHoliday } from './NewHoliday';
+export type Holiday = (NewHoliday & {
+        /**
+         * Unique identifier for the holiday
+         */
+        id: string;
+        /**
+         * Tenant ID the holiday belongs to
+         */
+        tenant_id: string;
+        /**
+         * When the holiday was created
+         */
+        created_at: string;
+        /**
+         * When the holiday was last updated
+         */
+        updated_at: string;
+});
+
@@ -10,9 +10,24 @@ export type JobAuditLog = {
     scheduled_time: string;
     start_time?: string;
     end_time?: string;
+    /**
+     * Seconds between the scheduled time and the start time
+     */
+    delay?: number;
     status: string;
     output?: string;
     error?: string;
+    result?: Record<string, any>;
+    attempt?: number;
+    parent_id?: string;
+    /**
+     * Random delay in milliseconds applied before the run started
+     */
+    jitter_ms?: number;
+    /**
+     * ID of the workflow run the run belongs to
+     */
+    workflow_run_id?: string;
     tenantID: string;
     createdAt: string;
     updatedAt?: string;
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type NewBlackoutWindow = {
+    /**
+     * Name of the blackout window, e.g. the maintenance it covers
+     */
+    name: string;
+    /**
+     * When the blackout window starts
+     */
+    start_time: string;
+    /**
+     * When the blackout window ends, after its start
+     */
+    end_time: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type NewHoliday = {
+    /**
+     * Name of the holiday
+     */
+    name: string;
+    /**
+     * Day of the holiday, covered whole in the time zone of each job
+     */
+    date: string;
+};
+
@@ -0,0 +1,27 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type NewRegisteredJob = {
+    /**
+     * Name of the job, unique for the tenant
+     */
+    job_name: string;
+    /**
+     * Type of the job, whose factory builds the job on every instance
+     */
+    job_type: string;
+    /**
+     * Schedule of the job, a cron expression or an RFC 5545 recurrence rule
+     */
+    schedule: string;
+    /**
+     * Configuration of the job, passed to the factory and delivered to the job at run time
+     */
+    payload?: Record<string, any>;
+    /**
+     * Whether the job is enabled
+     */
+    is_enabled?: boolean;
+};
+
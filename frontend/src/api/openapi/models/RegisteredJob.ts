@@ -16,9 +16,33 @@ export type RegisteredJob = {
      */
     description?: string;
     /**
-     * Cron schedule expression
+     * Schedule defined in code, a cron expression or an RFC 5545 recurrence rule
      */
     schedule: string;
+    /**
+     * Type of the schedule defined in code, a cron expression or an RFC 5545 recurrence rule
+     */
+    schedule_type: RegisteredJob.schedule_type;
+    /**
+     * Cron schedule expression set through the API, used instead of schedule
+     */
+    schedule_override?: string;
+    /**
+     * Time zone the schedule is evaluated in (IANA name), the time zone of the server when not set
+     */
+    time_zone?: string;
+    /**
+     * Configuration of the job set through the API, delivered to the job at run time
+     */
+    payload?: Record<string, any>;
+    /**
+     * Type of the job defined through the API, not set for jobs registered in code
+     */
+    job_type?: string;
+    /**
+     * Whether the job is registered in code or defined through the API
+     */
+    source: RegisteredJob.source;
     /**
      * Whether this is a long-running job that needs heartbeats
      */
@@ -47,5 +71,25 @@ export type RegisteredJob = {
      * When the job was last updated
      */
     updated_at: string;
+    /**
+     * Next run times of the effective schedule
+     */
+    next_run_times?: Array<string>;
 };
+export namespace RegisteredJob {
+    /**
+     * Type of the schedule defined in code, a cron expression or an RFC 5545 recurrence rule
+     */
+    export enum schedule_type {
+        CRON = 'cron',
+        RRULE = 'rrule',
+    }
+    /**
+     * Whether the job is registered in code or defined through the API
+     */
+    export enum source {
+        CODE = 'code',
+        API = 'api',
+    }
+}
 
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RunRequest = {
+    /**
+     * ID of the run request, also recorded as request ID in the audit log
+     */
+    request_id: string;
+};
+
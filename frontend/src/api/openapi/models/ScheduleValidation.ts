@@ -0,0 +1,27 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ScheduleValidation = {
+    /**
+     * Whether the expression and the time zone are valid
+     */
+    valid: boolean;
+    /**
+     * Parse error, when not valid
+     */
+    error?: string;
+    /**
+     * Name of the invalid field of the expression, when the error is about a single field
+     */
+    field?: string;
+    /**
+     * Offset of the invalid field in the expression, when the error is about a single field
+     */
+    position?: number;
+    /**
+     * Next run times of the schedule, when valid
+     */
+    next_run_times?: Array<string>;
+};
+
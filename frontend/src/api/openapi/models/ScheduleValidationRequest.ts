@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type ScheduleValidationRequest = {
+    /**
+     * Cron schedule expression, with 6 fields starting with seconds, 5 fields, a descriptor such as @daily, an @every duration or an RFC 5545 recurrence rule
+     */
+    expression: string;
+    /**
+     * Time zone the schedule is evaluated in (IANA name), the time zone of the server when not set
+     */
+    time_zone?: string;
+    /**
+     * Number of next run times to return
+     */
+    count?: number;
+};
+
@@ -0,0 +1,45 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WorkflowEdge } from './WorkflowEdge';
+export type Workflow = {
+    /**
+     * Unique identifier for the workflow
+     */
+    id: string;
+    /**
+     * Name of the workflow, registered in code
+     */
+    name: string;
+    /**
+     * Whether a job that fails or does not run skips the jobs not triggered yet (stop) or not (continue)
+     */
+    failure_policy: Workflow.failure_policy;
+    /**
+     * Edges of the DAG of the workflow
+     */
+    edges: Array<WorkflowEdge>;
+    /**
+     * Tenant ID the workflow belongs to
+     */
+    tenant_id: string;
+    /**
+     * When the workflow was first registered
+     */
+    created_at: string;
+    /**
+     * When the workflow was last registered
+     */
+    updated_at: string;
+};
+export namespace Workflow {
+    /**
+     * Whether a job that fails or does not run skips the jobs not triggered yet (stop) or not (continue)
+     */
+    export enum failure_policy {
+        STOP = 'stop',
+        CONTINUE = 'continue',
+    }
+}
+
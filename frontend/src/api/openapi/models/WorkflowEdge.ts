@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type WorkflowEdge = {
+    /**
+     * Name of the job running first
+     */
+    upstream_job: string;
+    /**
+     * Name of the job triggered once all its upstream jobs finished
+     */
+    downstream_job: string;
+};
+
@@ -0,0 +1,41 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type WorkflowRun = {
+    /**
+     * Unique identifier for the workflow run, also set as workflow_run_id on the audit logs of its jobs
+     */
+    id: string;
+    /**
+     * ID of the workflow
+     */
+    workflow_id: string;
+    /**
+     * Status of the run, failed when any of its jobs did not complete
+     */
+    status: WorkflowRun.status;
+    /**
+     * When the root job of the workflow finished, starting the run
+     */
+    started_at: string;
+    /**
+     * When the last job of the run finished
+     */
+    ended_at?: string;
+    /**
+     * Tenant ID the workflow run belongs to
+     */
+    tenant_id: string;
+};
+export namespace WorkflowRun {
+    /**
+     * Status of the run, failed when any of its jobs did not complete
+     */
+    export enum status {
+        RUNNING = 'running',
+        COMPLETED = 'completed',
+        FAILED = 'failed',
+    }
+}
+
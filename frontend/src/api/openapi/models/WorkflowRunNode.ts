@@ -0,0 +1,35 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type WorkflowRunNode = {
+    /**
+     * Name of the job
+     */
+    job_name: string;
+    /**
+     * Status of the job in the workflow run
+     */
+    status: WorkflowRunNode.status;
+    /**
+     * ID of the audit log of the run of the job
+     */
+    audit_log_id?: string;
+    /**
+     * When the status of the job last changed
+     */
+    updated_at: string;
+};
+export namespace WorkflowRunNode {
+    /**
+     * Status of the job in the workflow run
+     */
+    export enum status {
+        PENDING = 'pending',
+        RUNNING = 'running',
+        COMPLETED = 'completed',
+        FAILED = 'failed',
+        SKIPPED = 'skipped',
+    }
+}
+
@@ -0,0 +1,22 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WorkflowEdge } from './WorkflowEdge';
+import type { WorkflowRun } from './WorkflowRun';
+import type { WorkflowRunNode } from './WorkflowRunNode';
+export type WorkflowRunState = (WorkflowRun & {
+        /**
+         * Name of the workflow
+         */
+        workflow_name: string;
+        /**
+         * State of each job of the workflow run
+         */
+        nodes: Array<WorkflowRunNode>;
+        /**
+         * Edges of the DAG of the workflow
+         */
+        edges: Array<WorkflowEdge>;
+});
+
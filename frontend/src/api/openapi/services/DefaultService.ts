@@ -2,9 +2,20 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { BlackoutWindow } from '../models/BlackoutWindow';
+import type { Holiday } from '../models/Holiday';
 import type { Job } from '../models/Job';
 import type { JobAuditLog } from '../models/JobAuditLog';
+import type { NewBlackoutWindow } from '../models/NewBlackoutWindow';
+import type { NewHoliday } from '../models/NewHoliday';
+import type { NewRegisteredJob } from '../models/NewRegisteredJob';
 import type { RegisteredJob } from '../models/RegisteredJob';
+import type { RunRequest } from '../models/RunRequest';
+import type { ScheduleValidation } from '../models/ScheduleValidation';
+import type { ScheduleValidationRequest } from '../models/ScheduleValidationRequest';
+import type { Workflow } from '../models/Workflow';
+import type { WorkflowRun } from '../models/WorkflowRun';
+import type { WorkflowRunState } from '../models/WorkflowRunState';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
@@ -213,6 +224,28 @@ export class DefaultService {
             },
         });
     }
+    /**
+     * Defines a job of a registered job type for the tenant. Every instance knowing the job type builds and schedules it.
+     * @param requestBody Job to define
+     * @returns RegisteredJob Job defined
+     * @throws ApiError
+     */
+    public static createRegisteredJob(
+        requestBody: NewRegisteredJob,
+    ): CancelablePromise<RegisteredJob> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cron/registered-jobs',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                409: `A job with this name already exists`,
+                500: `Internal server error`,
+            },
+        });
+    }
     /**
      * Get a registered job by ID
      * @param id ID of registered job to fetch
@@ -236,7 +269,7 @@ export class DefaultService {
         });
     }
     /**
-     * Update a registered job (currently only enables/disables)
+     * Update a registered job (enables/disables it, overrides its schedule or sets its payload)
      * @param id ID of registered job to update
      * @param requestBody
      * @returns RegisteredJob Updated job details
@@ -249,6 +282,22 @@ export class DefaultService {
              * Whether the job is enabled
              */
             is_enabled?: boolean;
+            /**
+             * Cron schedule expression replacing the schedule defined in code
+             */
+            schedule_override?: string;
+            /**
+             * Remove the schedule override and revert to the schedule defined in code
+             */
+            reset_schedule?: boolean;
+            /**
+             * Configuration of the job, replacing its current payload
+             */
+            payload?: Record<string, any>;
+            /**
+             * Remove the payload of the job
+             */
+            reset_payload?: boolean;
         },
     ): CancelablePromise<RegisteredJob> {
         return __request(OpenAPI, {
@@ -267,6 +316,29 @@ export class DefaultService {
             },
         });
     }
+    /**
+     * Deletes a job defined through the API. Jobs registered in code cannot be deleted.
+     * @param id ID of registered job to delete
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteRegisteredJob(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cron/registered-jobs/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Job not found`,
+                409: `Job registered in code`,
+                500: `Internal server error`,
+            },
+        });
+    }
     /**
      * Get audit logs for a specific registered job
      * @param id ID of registered job to fetch
@@ -305,6 +377,328 @@ export class DefaultService {
             },
         });
     }
+    /**
+     * Run a registered job now, outside its schedule. The run is dispatched to an instance holding the job.
+     * @param id ID of registered job to run
+     * @returns RunRequest Run requested
+     * @throws ApiError
+     */
+    public static runRegisteredJob(
+        id: string,
+    ): CancelablePromise<RunRequest> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cron/registered-jobs/{id}/run',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Job not found`,
+                409: `Job is disabled`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Validate a cron schedule expression and preview its next run times, e.g. before saving a schedule override
+     * @param requestBody Schedule to validate
+     * @returns ScheduleValidation Validation result, with the next run times when the schedule is valid
+     * @throws ApiError
+     */
+    public static validateSchedule(
+        requestBody: ScheduleValidationRequest,
+    ): CancelablePromise<ScheduleValidation> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cron/schedules/validate',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Returns the blackout windows of the tenant
+     * @returns BlackoutWindow Blackout window list
+     * @throws ApiError
+     */
+    public static listBlackoutWindows(): CancelablePromise<Array<BlackoutWindow>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/blackout-windows',
+            errors: {
+                401: `Unauthorized`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Creates a blackout window. Scheduled runs of the jobs of the tenant are suppressed during it.
+     * @param requestBody Blackout window to create
+     * @returns BlackoutWindow Blackout window created
+     * @throws ApiError
+     */
+    public static createBlackoutWindow(
+        requestBody: NewBlackoutWindow,
+    ): CancelablePromise<BlackoutWindow> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cron/blackout-windows',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns a blackout window based on a single ID
+     * @param id ID of blackout window to fetch
+     * @returns BlackoutWindow Blackout window response
+     * @throws ApiError
+     */
+    public static getBlackoutWindow(
+        id: string,
+    ): CancelablePromise<BlackoutWindow> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/blackout-windows/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Blackout window not found`,
+            },
+        });
+    }
+    /**
+     * Updates a blackout window
+     * @param id ID of blackout window to update
+     * @param requestBody Updated blackout window
+     * @returns BlackoutWindow Updated blackout window
+     * @throws ApiError
+     */
+    public static updateBlackoutWindow(
+        id: string,
+        requestBody: NewBlackoutWindow,
+    ): CancelablePromise<BlackoutWindow> {
+        return __request(OpenAPI, {
+            method: 'PUT',
+            url: '/api/v1/cron/blackout-windows/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                404: `Blackout window not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Deletes a single blackout window based on the ID supplied
+     * @param id ID of blackout window to delete
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteBlackoutWindow(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cron/blackout-windows/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Blackout window not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns the holidays of the tenant
+     * @returns Holiday Holiday list
+     * @throws ApiError
+     */
+    public static listHolidays(): CancelablePromise<Array<Holiday>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/holidays',
+            errors: {
+                401: `Unauthorized`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Creates a holiday. Scheduled runs of the jobs of the tenant are suppressed during it.
+     * @param requestBody Holiday to create
+     * @returns Holiday Holiday created
+     * @throws ApiError
+     */
+    public static createHoliday(
+        requestBody: NewHoliday,
+    ): CancelablePromise<Holiday> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/v1/cron/holidays',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns a holiday based on a single ID
+     * @param id ID of holiday to fetch
+     * @returns Holiday Holiday response
+     * @throws ApiError
+     */
+    public static getHoliday(
+        id: string,
+    ): CancelablePromise<Holiday> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/holidays/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Holiday not found`,
+            },
+        });
+    }
+    /**
+     * Updates a holiday
+     * @param id ID of holiday to update
+     * @param requestBody Updated holiday
+     * @returns Holiday Updated holiday
+     * @throws ApiError
+     */
+    public static updateHoliday(
+        id: string,
+        requestBody: NewHoliday,
+    ): CancelablePromise<Holiday> {
+        return __request(OpenAPI, {
+            method: 'PUT',
+            url: '/api/v1/cron/holidays/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                404: `Holiday not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Deletes a single holiday based on the ID supplied
+     * @param id ID of holiday to delete
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteHoliday(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/v1/cron/holidays/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Holiday not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns the workflows of the tenant with the edges of their DAG
+     * @returns Workflow Workflow list
+     * @throws ApiError
+     */
+    public static listWorkflows(): CancelablePromise<Array<Workflow>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/workflows',
+            errors: {
+                401: `Unauthorized`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns the runs of a workflow, most recent first
+     * @param id ID of the workflow
+     * @param page page number
+     * @param pageSize maximum number of results to return
+     * @returns WorkflowRun Workflow run list
+     * @throws ApiError
+     */
+    public static listWorkflowRuns(
+        id: string,
+        page: number = 1,
+        pageSize: number = 10,
+    ): CancelablePromise<Array<WorkflowRun>> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/workflows/{id}/runs',
+            path: {
+                'id': id,
+            },
+            query: {
+                'page': page,
+                'pageSize': pageSize,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Workflow not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
+    /**
+     * Returns a workflow run with the state of each job of its DAG
+     * @param id ID of the workflow run
+     * @returns WorkflowRunState Workflow run state
+     * @throws ApiError
+     */
+    public static getWorkflowRun(
+        id: string,
+    ): CancelablePromise<WorkflowRunState> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/v1/cron/workflow-runs/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Workflow run not found`,
+                500: `Internal server error`,
+            },
+        });
+    }
     /**
      * Apply pending migrations
      * @returns any Migrations applied successfully
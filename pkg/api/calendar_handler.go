@@ -0,0 +1,318 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	access "ctoup.com/coreapp/pkg/shared/service"
+	api "github.com/cto-up/cron-lib/api/openapi"
+	"github.com/cto-up/cron-lib/pkg/db"
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// CalendarHandler manages the blackout windows and holidays during which scheduled runs are suppressed
+type CalendarHandler struct {
+	store *db.Store
+}
+
+func newCalendarHandler(store *db.Store) *CalendarHandler {
+	return &CalendarHandler{
+		store: store,
+	}
+}
+
+// ListBlackoutWindows godoc
+func (h *CalendarHandler) ListBlackoutWindows(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	windows, err := h.store.ListBlackoutWindows(c, tenantID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiWindows := make([]api.BlackoutWindow, 0, len(windows))
+	for _, window := range windows {
+		apiWindows = append(apiWindows, toAPIBlackoutWindow(window))
+	}
+
+	c.JSON(http.StatusOK, apiWindows)
+}
+
+// CreateBlackoutWindow godoc
+func (h *CalendarHandler) CreateBlackoutWindow(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	var req api.CreateBlackoutWindowJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.EndTime.After(req.StartTime) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
+
+	window, err := h.store.CreateBlackoutWindow(c, repository.CreateBlackoutWindowParams{
+		Name:      req.Name,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		TenantID:  tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAPIBlackoutWindow(window))
+}
+
+// GetBlackoutWindow godoc
+func (h *CalendarHandler) GetBlackoutWindow(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	window, err := h.store.GetBlackoutWindowByID(c, repository.GetBlackoutWindowByIDParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "blackout window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIBlackoutWindow(window))
+}
+
+// UpdateBlackoutWindow godoc
+func (h *CalendarHandler) UpdateBlackoutWindow(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	var req api.UpdateBlackoutWindowJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !req.EndTime.After(req.StartTime) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
+
+	window, err := h.store.UpdateBlackoutWindow(c, repository.UpdateBlackoutWindowParams{
+		Name:      req.Name,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		ID:        id,
+		TenantID:  tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "blackout window not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIBlackoutWindow(window))
+}
+
+// DeleteBlackoutWindow godoc
+func (h *CalendarHandler) DeleteBlackoutWindow(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	result, err := h.store.DeleteBlackoutWindow(c, repository.DeleteBlackoutWindowParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if result.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "blackout window not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListHolidays godoc
+func (h *CalendarHandler) ListHolidays(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	holidays, err := h.store.ListHolidays(c, tenantID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiHolidays := make([]api.Holiday, 0, len(holidays))
+	for _, holiday := range holidays {
+		apiHolidays = append(apiHolidays, toAPIHoliday(holiday))
+	}
+
+	c.JSON(http.StatusOK, apiHolidays)
+}
+
+// CreateHoliday godoc
+func (h *CalendarHandler) CreateHoliday(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	var req api.CreateHolidayJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday, err := h.store.CreateHoliday(c, repository.CreateHolidayParams{
+		Name:        req.Name,
+		HolidayDate: req.Date.Time,
+		TenantID:    tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAPIHoliday(holiday))
+}
+
+// GetHoliday godoc
+func (h *CalendarHandler) GetHoliday(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	holiday, err := h.store.GetHolidayByID(c, repository.GetHolidayByIDParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "holiday not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIHoliday(holiday))
+}
+
+// UpdateHoliday godoc
+func (h *CalendarHandler) UpdateHoliday(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	var req api.UpdateHolidayJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holiday, err := h.store.UpdateHoliday(c, repository.UpdateHolidayParams{
+		Name:        req.Name,
+		HolidayDate: req.Date.Time,
+		ID:          id,
+		TenantID:    tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "holiday not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIHoliday(holiday))
+}
+
+// DeleteHoliday godoc
+func (h *CalendarHandler) DeleteHoliday(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	result, err := h.store.DeleteHoliday(c, repository.DeleteHolidayParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if result.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "holiday not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// toAPIBlackoutWindow converts a blackout window to its API response format
+func toAPIBlackoutWindow(window repository.CronBlackoutWindow) api.BlackoutWindow {
+	return api.BlackoutWindow{
+		Id:        window.ID,
+		Name:      window.Name,
+		StartTime: window.StartTime,
+		EndTime:   window.EndTime,
+		TenantId:  window.TenantID,
+		CreatedAt: window.CreatedAt,
+		UpdatedAt: window.UpdatedAt,
+	}
+}
+
+// toAPIHoliday converts a holiday to its API response format
+func toAPIHoliday(holiday repository.CronHoliday) api.Holiday {
+	return api.Holiday{
+		Id:        holiday.ID,
+		Name:      holiday.Name,
+		Date:      types.Date{Time: holiday.HolidayDate},
+		TenantId:  holiday.TenantID,
+		CreatedAt: holiday.CreatedAt,
+		UpdatedAt: holiday.UpdatedAt,
+	}
+}
@@ -21,6 +21,9 @@ type CronHandler struct {
 	*MigrationHandler
 	*SeedHandler
 	*RegisteredJobHandler
+	*ScheduleHandler
+	*CalendarHandler
+	*WorkflowHandler
 }
 
 func RegisterHandler(connPool *pgxpool.Pool, firebaseTenantClientPool *access.FirebaseTenantClientConnectionPool, openaiOptions core.GinServerOptions, router *gin.Engine) {
@@ -47,7 +50,10 @@ func RegisterHandler(connPool *pgxpool.Pool, firebaseTenantClientPool *access.Fi
 		JobAuditLogHandler:   newJobAuditLogHandler(store, firebaseTenantClientPool),
 		MigrationHandler:     newMigrationHandler(store),
 		SeedHandler:          newSeedHandler(service.NewSeedService(connPool)),
-		RegisteredJobHandler: newRegisteredJobHandler(store, firebaseTenantClientPool),
+		RegisteredJobHandler: newRegisteredJobHandler(store, firebaseTenantClientPool, jobManager),
+		ScheduleHandler:      newScheduleHandler(),
+		CalendarHandler:      newCalendarHandler(store),
+		WorkflowHandler:      newWorkflowHandler(store),
 	}
 	api.RegisterHandlersWithOptions(router, handler, options)
 }
@@ -6,6 +6,7 @@ import (
 
 	"ctoup.com/coreapp/pkg/shared/repository/subentity"
 	access "ctoup.com/coreapp/pkg/shared/service"
+	"ctoup.com/coreapp/pkg/shared/util"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -61,7 +62,7 @@ func (h *JobAuditLogHandler) GetJobAuditLogByID(c *gin.Context, id types.UUID) {
 		return
 	}
 
-	c.JSON(http.StatusOK, jobAuditLog)
+	c.JSON(http.StatusOK, toAPIJobAuditLog(jobAuditLog))
 }
 
 // ListJobAuditLogs implements api.ServerInterface.
@@ -120,8 +121,45 @@ func (h *JobAuditLogHandler) ListJobAuditLogs(c *gin.Context, params api.ListJob
 		}
 		c.JSON(http.StatusOK, basicEntities)
 	} else {
-		c.JSON(http.StatusOK, jobAuditLogs)
+		apiJobAuditLogs := make([]api.JobAuditLog, 0, len(jobAuditLogs))
+		for _, jobAuditLog := range jobAuditLogs {
+			apiJobAuditLogs = append(apiJobAuditLogs, toAPIJobAuditLog(jobAuditLog))
+		}
+		c.JSON(http.StatusOK, apiJobAuditLogs)
+	}
+}
+
+// toAPIJobAuditLog converts a job audit log to its API representation
+func toAPIJobAuditLog(jobAuditLog repository.CronJobAuditLog) api.JobAuditLog {
+	attempt := int(jobAuditLog.Attempt)
+	apiJobAuditLog := api.JobAuditLog{
+		Id:            jobAuditLog.ID,
+		AppId:         jobAuditLog.AppID,
+		RequestId:     jobAuditLog.RequestID,
+		JobName:       jobAuditLog.JobName,
+		ScheduledTime: jobAuditLog.ScheduledTime.Time,
+		StartTime:     util.FromNullableTimestamp(jobAuditLog.StartTime),
+		EndTime:       util.FromNullableTimestamp(jobAuditLog.EndTime),
+		Status:        jobAuditLog.Status,
+		Output:        util.FromNullableText(jobAuditLog.Output),
+		Error:         util.FromNullableText(jobAuditLog.Error),
+		Result:        fromNullableJSON(jobAuditLog.Result),
+		Attempt:       &attempt,
+		ParentId:      fromNullableUUID(jobAuditLog.ParentID),
+		JitterMs:      fromNullableInt4(jobAuditLog.JitterMs),
+		WorkflowRunId: fromNullableUUID(jobAuditLog.WorkflowRunID),
+		TenantID:      jobAuditLog.TenantID,
+		CreatedAt:     jobAuditLog.CreatedAt.Time,
+		UpdatedAt:     util.FromNullableTimestamp(jobAuditLog.UpdatedAt),
+	}
+
+	// Scheduling latency, e.g. under load
+	if jobAuditLog.ScheduledTime.Valid && jobAuditLog.StartTime.Valid {
+		delay := jobAuditLog.StartTime.Time.Sub(jobAuditLog.ScheduledTime.Time).Seconds()
+		apiJobAuditLog.Delay = &delay
 	}
+
+	return apiJobAuditLog
 }
 
 func newJobAuditLogHandler(store *db.Store, authClientPool *access.FirebaseTenantClientConnectionPool) *JobAuditLogHandler {
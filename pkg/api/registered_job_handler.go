@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
@@ -9,21 +10,28 @@ import (
 	access "ctoup.com/coreapp/pkg/shared/service"
 	"ctoup.com/coreapp/pkg/shared/util"
 	api "github.com/cto-up/cron-lib/api/openapi"
+	"github.com/cto-up/cron-lib/pkg"
 	"github.com/cto-up/cron-lib/pkg/db"
 	"github.com/cto-up/cron-lib/pkg/db/repository"
+	"github.com/cto-up/cron-lib/pkg/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/oapi-codegen/runtime/types"
 )
 
 type RegisteredJobHandler struct {
 	store          *db.Store
 	authClientPool *access.FirebaseTenantClientConnectionPool
+	jobManager     *cron.JobManager
 }
 
-func newRegisteredJobHandler(store *db.Store, authClientPool *access.FirebaseTenantClientConnectionPool) *RegisteredJobHandler {
+func newRegisteredJobHandler(store *db.Store, authClientPool *access.FirebaseTenantClientConnectionPool, jobManager *cron.JobManager) *RegisteredJobHandler {
 	return &RegisteredJobHandler{
 		store:          store,
 		authClientPool: authClientPool,
+		jobManager:     jobManager,
 	}
 }
 
@@ -69,22 +77,9 @@ func (h *RegisteredJobHandler) ListRegisteredJobs(c *gin.Context, params api.Lis
 	}
 
 	// Convert to API response format
-	apiJobs := []api.RegisteredJob{}
+	apiJobs := make([]api.RegisteredJob, 0, len(jobs))
 	for _, job := range jobs {
-		apiJob := api.RegisteredJob{
-			Id:               job.ID,
-			JobName:          job.JobName,
-			Schedule:         job.Schedule,
-			IsLongRunning:    job.IsLongRunning,
-			IsEnabled:        job.IsEnabled,
-			LastRegisteredAt: job.LastRegisteredAt,
-			InstanceId:       job.InstanceID,
-			TenantId:         job.TenantID,
-			CreatedAt:        job.CreatedAt,
-			UpdatedAt:        job.UpdatedAt,
-		}
-
-		apiJobs = append(apiJobs, apiJob)
+		apiJobs = append(apiJobs, toAPIRegisteredJob(job.CronRegisteredJob))
 	}
 
 	c.JSON(http.StatusOK, apiJobs)
@@ -109,17 +104,16 @@ func (h *RegisteredJobHandler) GetRegisteredJob(c *gin.Context, jobID types.UUID
 	}
 
 	// Convert to API response format
-	apiJob := api.RegisteredJob{
-		Id:               job.ID,
-		JobName:          job.JobName,
-		Schedule:         job.Schedule,
-		IsLongRunning:    job.IsLongRunning,
-		IsEnabled:        job.IsEnabled,
-		LastRegisteredAt: job.LastRegisteredAt,
-		InstanceId:       job.InstanceID,
-		TenantId:         job.TenantID,
-		CreatedAt:        job.CreatedAt,
-		UpdatedAt:        job.UpdatedAt,
+	apiJob := toAPIRegisteredJob(job)
+
+	// Next run times of the effective schedule, omitted when it cannot be evaluated
+	schedule := job.Schedule
+	if job.ScheduleOverride.Valid {
+		schedule = job.ScheduleOverride.String
+	}
+	seed := utils.ScheduleSeed(job.TenantID, job.JobName)
+	if runTimes, err := nextRunTimes(schedule, job.TimeZone.String, seed, defaultNextRunTimesCount); err == nil {
+		apiJob.NextRunTimes = &runTimes
 	}
 
 	c.JSON(http.StatusOK, apiJob)
@@ -134,27 +128,108 @@ func (h *RegisteredJobHandler) UpdateRegisteredJob(c *gin.Context, jobID types.U
 	}
 
 	// Parse request body
-	var req struct {
-		IsEnabled *bool `json:"is_enabled"`
-	}
+	var req api.UpdateRegisteredJobJSONRequestBody
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Update job in database
-	if req.IsEnabled != nil {
-		_, err := h.store.UpdateRegisteredJobEnabled(c, repository.UpdateRegisteredJobEnabledParams{
-			ID:        jobID,
-			TenantID:  tenantID.(string),
-			IsEnabled: *req.IsEnabled,
-		})
+	resetSchedule := req.ResetSchedule != nil && *req.ResetSchedule
+	if resetSchedule && req.ScheduleOverride != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "schedule_override and reset_schedule cannot be used together"})
+		return
+	}
+
+	resetPayload := req.ResetPayload != nil && *req.ResetPayload
+	if resetPayload && req.Payload != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "payload and reset_payload cannot be used together"})
+		return
+	}
+
+	// Validate the schedule override with the same parser as the scheduler, and store it normalized
+	if req.ScheduleOverride != nil {
+		normalized, err := utils.NormalizeSchedule(*req.ScheduleOverride)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.ScheduleOverride = &normalized
+	}
+
+	// Running jobs read their payload when they start
+	updatePayload := req.Payload != nil || resetPayload
+	var payload json.RawMessage
+	if updatePayload {
+		if req.Payload != nil {
+			encoded, err := json.Marshal(*req.Payload)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			payload = encoded
+		}
+		if err := h.validateDefinedJobPayload(c, jobID, tenantID.(string), payload); err != nil {
+			c.JSON(statusOf(err), gin.H{"error": err.Error()})
 			return
 		}
 	}
 
+	// Update job in database, all the changes or none of them.
+	// Running job managers pick up the new schedule on their next sync.
+	err := h.store.ExecTx(c, func(q *repository.Queries) error {
+		var results []pgconn.CommandTag
+		if req.IsEnabled != nil {
+			result, err := q.UpdateRegisteredJobEnabled(c, repository.UpdateRegisteredJobEnabledParams{
+				ID:        jobID,
+				TenantID:  tenantID.(string),
+				IsEnabled: *req.IsEnabled,
+			})
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		if req.ScheduleOverride != nil || resetSchedule {
+			scheduleOverride := pgtype.Text{}
+			if req.ScheduleOverride != nil {
+				scheduleOverride = pgtype.Text{String: *req.ScheduleOverride, Valid: true}
+			}
+			result, err := q.UpdateRegisteredJobScheduleOverride(c, repository.UpdateRegisteredJobScheduleOverrideParams{
+				ID:               jobID,
+				TenantID:         tenantID.(string),
+				ScheduleOverride: scheduleOverride,
+			})
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		if updatePayload {
+			result, err := q.UpdateRegisteredJobPayload(c, repository.UpdateRegisteredJobPayloadParams{
+				ID:       jobID,
+				TenantID: tenantID.(string),
+				Payload:  payload,
+			})
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		for _, result := range results {
+			if result.RowsAffected() == 0 {
+				return errJobNotFound
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(statusOf(err), gin.H{"error": err.Error()})
+		return
+	}
+
 	// Get updated job
 	job, err := h.store.GetRegisteredJobByID(c, repository.GetRegisteredJobByIDParams{
 		ID:       jobID,
@@ -166,22 +241,178 @@ func (h *RegisteredJobHandler) UpdateRegisteredJob(c *gin.Context, jobID types.U
 	}
 
 	// Convert to API response format
-	apiJob := api.RegisteredJob{
-		Id:               job.ID,
-		JobName:          job.JobName,
-		Schedule:         job.Schedule,
-		IsLongRunning:    job.IsLongRunning,
-		IsEnabled:        job.IsEnabled,
-		LastRegisteredAt: job.LastRegisteredAt,
-		InstanceId:       job.InstanceID,
-		TenantId:         job.TenantID,
-		CreatedAt:        job.CreatedAt,
-		UpdatedAt:        job.UpdatedAt,
-	}
+	apiJob := toAPIRegisteredJob(job)
 
 	c.JSON(http.StatusOK, apiJob)
 }
 
+// CreateRegisteredJob godoc
+func (h *RegisteredJobHandler) CreateRegisteredJob(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	var req api.CreateRegisteredJobJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.JobName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_name is required"})
+		return
+	}
+	if !h.jobManager.IsJobTypeRegistered(req.JobType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown job type " + req.JobType})
+		return
+	}
+
+	// Validate the schedule with the same parser as the scheduler, and store it normalized
+	schedule, err := utils.NormalizeSchedule(req.Schedule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var payload json.RawMessage
+	if req.Payload != nil {
+		payload, err = json.Marshal(*req.Payload)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// The job is built by every instance holding its type on each sync, a spec its factory
+	// rejects would never run
+	err = h.jobManager.ValidateJob(req.JobType, cron.JobSpec{
+		Name:     req.JobName,
+		TenantID: tenantID.(string),
+		Schedule: schedule,
+		Payload:  payload,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job: " + err.Error()})
+		return
+	}
+
+	// Job names are unique per tenant, whether registered in code or defined through the API
+	_, err = h.store.GetRegisteredJobByName(c, repository.GetRegisteredJobByNameParams{
+		JobName:  req.JobName,
+		TenantID: tenantID.(string),
+	})
+	if err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "a job with this name already exists"})
+		return
+	}
+	if err.Error() != pgx.ErrNoRows.Error() {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Running job managers build and schedule the job on their next sync
+	job, err := h.store.CreateDefinedJob(c, repository.CreateDefinedJobParams{
+		JobName:      req.JobName,
+		JobType:      req.JobType,
+		Schedule:     schedule,
+		ScheduleType: utils.ScheduleTypeOf(schedule),
+		Payload:      payload,
+		IsEnabled:    req.IsEnabled == nil || *req.IsEnabled,
+		TenantID:     tenantID.(string),
+	})
+	if err != nil {
+		// A job with the same name was created since the check above
+		if isUniqueViolation(err) {
+			c.JSON(http.StatusConflict, gin.H{"error": "a job with this name already exists"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, toAPIRegisteredJob(job))
+}
+
+// DeleteRegisteredJob godoc
+func (h *RegisteredJobHandler) DeleteRegisteredJob(c *gin.Context, jobID types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	job, err := h.store.GetRegisteredJobByID(c, repository.GetRegisteredJobByIDParams{
+		ID:       jobID,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	// Jobs registered in code are registered again by their instance
+	if job.Source != cron.JobSourceAPI {
+		c.JSON(http.StatusConflict, gin.H{"error": "job is registered in code"})
+		return
+	}
+
+	// Running job managers remove the job on their next sync
+	result, err := h.store.DeleteDefinedJob(c, repository.DeleteDefinedJobParams{
+		ID:       jobID,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if result.RowsAffected() == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RunRegisteredJob godoc
+func (h *RegisteredJobHandler) RunRegisteredJob(c *gin.Context, jobID types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+	userID, exists := c.Get(access.AUTH_USER_ID)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("UserID not found"))
+		return
+	}
+
+	job, err := h.store.GetRegisteredJobByID(c, repository.GetRegisteredJobByIDParams{
+		ID:       jobID,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "job not found"})
+		return
+	}
+	if !job.IsEnabled {
+		c.JSON(http.StatusConflict, gin.H{"error": "job is disabled"})
+		return
+	}
+	// Otherwise the run request would expire without any instance running it
+	if !h.jobManager.IsRegistrationActive(job.LastRegisteredAt) {
+		c.JSON(http.StatusConflict, gin.H{"error": "no running instance holds the job"})
+		return
+	}
+
+	requestID, err := h.jobManager.TriggerJob(c, job.JobName, job.TenantID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, api.RunRequest{RequestId: requestID})
+}
+
 // GetJobAuditLogs godoc
 func (h *RegisteredJobHandler) GetJobAuditLogs(c *gin.Context, jobID types.UUID, params api.GetJobAuditLogsParams) {
 	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
@@ -232,26 +463,126 @@ func (h *RegisteredJobHandler) GetJobAuditLogs(c *gin.Context, jobID types.UUID,
 	_ = totalCount
 
 	// Convert to API response format
-	var apiAuditLogs []api.JobAuditLog
-	for _, log := range auditLogs {
-		apiAuditLog := api.JobAuditLog{
-			Id:            log.ID,
-			AppId:         log.AppID,
-			RequestId:     log.RequestID,
-			JobName:       log.JobName,
-			ScheduledTime: *util.FromNullableTimestamp(log.ScheduledTime),
-			StartTime:     util.FromNullableTimestamp(log.StartTime),
-			EndTime:       util.FromNullableTimestamp(log.EndTime),
-			Status:        log.Status,
-			Output:        util.FromNullableText(log.Output),
-			Error:         util.FromNullableText(log.Error),
-			TenantID:      log.TenantID,
-			CreatedAt:     log.CreatedAt.Time,
-			UpdatedAt:     util.FromNullableTimestamp(log.UpdatedAt),
+	apiAuditLogs := make([]api.JobAuditLog, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		apiAuditLogs = append(apiAuditLogs, toAPIJobAuditLog(auditLog))
+	}
+
+	c.JSON(http.StatusOK, apiAuditLogs)
+}
+
+// errJobNotFound is the error of a registered job that does not exist for the tenant
+var errJobNotFound = errors.New("job not found")
+
+// validateDefinedJobPayload checks that a job defined through the API still builds with a new
+// payload, nil when reset. Jobs registered in code do not read their payload when built.
+func (h *RegisteredJobHandler) validateDefinedJobPayload(c *gin.Context, jobID types.UUID, tenantID string, payload json.RawMessage) error {
+	job, err := h.store.GetRegisteredJobByID(c, repository.GetRegisteredJobByIDParams{
+		ID:       jobID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			return errJobNotFound
 		}
+		return err
+	}
+	if job.Source != cron.JobSourceAPI {
+		return nil
+	}
 
-		apiAuditLogs = append(apiAuditLogs, apiAuditLog)
+	err = h.jobManager.ValidateJob(job.JobType.String, cron.JobSpec{
+		Name:     job.JobName,
+		TenantID: job.TenantID,
+		Schedule: job.Schedule,
+		Payload:  payload,
+	})
+	if err != nil {
+		return &invalidJobError{err: err}
 	}
+	return nil
+}
 
-	c.JSON(http.StatusOK, apiAuditLogs)
+// invalidJobError is the error of a job definition its factory rejects
+type invalidJobError struct {
+	err error
+}
+
+func (e *invalidJobError) Error() string {
+	return "invalid job: " + e.err.Error()
+}
+
+func (e *invalidJobError) Unwrap() error {
+	return e.err
+}
+
+// statusOf returns the HTTP status of an error returned while checking a registered job
+func statusOf(err error) int {
+	var invalid *invalidJobError
+	switch {
+	case errors.Is(err, errJobNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &invalid):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// isUniqueViolation returns whether err is a violation of a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// toAPIRegisteredJob converts a registered job to its API response format
+func toAPIRegisteredJob(job repository.CronRegisteredJob) api.RegisteredJob {
+	return api.RegisteredJob{
+		Id:               job.ID,
+		JobName:          job.JobName,
+		Schedule:         job.Schedule,
+		ScheduleOverride: util.FromNullableText(job.ScheduleOverride),
+		TimeZone:         util.FromNullableText(job.TimeZone),
+		ScheduleType:     api.RegisteredJobScheduleType(job.ScheduleType),
+		Payload:          fromNullableJSON(job.Payload),
+		JobType:          util.FromNullableText(job.JobType),
+		Source:           api.RegisteredJobSource(job.Source),
+		IsLongRunning:    job.IsLongRunning,
+		IsEnabled:        job.IsEnabled,
+		LastRegisteredAt: job.LastRegisteredAt,
+		InstanceId:       job.InstanceID,
+		TenantId:         job.TenantID,
+		CreatedAt:        job.CreatedAt,
+		UpdatedAt:        job.UpdatedAt,
+	}
+}
+
+// fromNullableJSON decodes a JSON object column, returning nil when it is NULL or not an object
+func fromNullableJSON(data json.RawMessage) *map[string]interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(data, &value); err != nil || value == nil {
+		return nil
+	}
+	return &value
+}
+
+// fromNullableUUID converts a nullable UUID column, returning nil when it is NULL
+func fromNullableUUID(id pgtype.UUID) *types.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := types.UUID(id.Bytes)
+	return &value
+}
+
+// fromNullableInt4 converts a nullable integer column, returning nil when it is NULL
+func fromNullableInt4(value pgtype.Int4) *int {
+	if !value.Valid {
+		return nil
+	}
+	converted := int(value.Int32)
+	return &converted
 }
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/cto-up/cron-lib/api/openapi"
+	"github.com/cto-up/cron-lib/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultNextRunTimesCount = 5
+	maxNextRunTimesCount     = 50
+)
+
+type ScheduleHandler struct{}
+
+func newScheduleHandler() *ScheduleHandler {
+	return &ScheduleHandler{}
+}
+
+// ValidateSchedule godoc
+func (h *ScheduleHandler) ValidateSchedule(c *gin.Context) {
+	var req api.ValidateScheduleJSONRequestBody
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	count := defaultNextRunTimesCount
+	if req.Count != nil {
+		count = *req.Count
+	}
+	if count < 1 || count > maxNextRunTimesCount {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count must be between 1 and 50"})
+		return
+	}
+
+	timeZone := ""
+	if req.TimeZone != nil {
+		timeZone = *req.TimeZone
+	}
+
+	// Invalid schedules are a validation result, not a bad request
+	// H tokens are previewed with an empty seed, each job spreads them with its own
+	runTimes, err := nextRunTimes(req.Expression, timeZone, "", count)
+	if err != nil {
+		message := err.Error()
+		validation := api.ScheduleValidation{
+			Valid: false,
+			Error: &message,
+		}
+		var scheduleErr *utils.ScheduleError
+		if errors.As(err, &scheduleErr) {
+			validation.Error = &scheduleErr.Message
+			if scheduleErr.Field != "" {
+				validation.Field = &scheduleErr.Field
+				validation.Position = &scheduleErr.Position
+			}
+		}
+		c.JSON(http.StatusOK, validation)
+		return
+	}
+
+	c.JSON(http.StatusOK, api.ScheduleValidation{
+		Valid:        true,
+		NextRunTimes: &runTimes,
+	})
+}
+
+// nextRunTimes returns the next count run times of a schedule evaluated in the given time zone,
+// the time zone of the server when empty. H tokens are resolved with the given seed, see utils.ScheduleSeed.
+func nextRunTimes(schedule string, timeZone string, seed string, count int) ([]time.Time, error) {
+	var loc *time.Location
+	if timeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Without a seed, NextRunTimes resolves H tokens and locates their errors
+	if seed != "" {
+		var err error
+		schedule, err = utils.ResolveHashedSchedule(schedule, seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return utils.NextRunTimes(schedule, loc, time.Now(), count)
+}
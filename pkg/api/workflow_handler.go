@@ -0,0 +1,214 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"ctoup.com/coreapp/api/helpers"
+	access "ctoup.com/coreapp/pkg/shared/service"
+	api "github.com/cto-up/cron-lib/api/openapi"
+	"github.com/cto-up/cron-lib/pkg/db"
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// WorkflowHandler exposes the workflows registered in code, their runs and the state of their DAG
+type WorkflowHandler struct {
+	store *db.Store
+}
+
+func newWorkflowHandler(store *db.Store) *WorkflowHandler {
+	return &WorkflowHandler{
+		store: store,
+	}
+}
+
+// ListWorkflows godoc
+func (h *WorkflowHandler) ListWorkflows(c *gin.Context) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	workflows, err := h.store.ListWorkflows(c, tenantID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiWorkflows := make([]api.Workflow, 0, len(workflows))
+	for _, workflow := range workflows {
+		edges, err := h.store.ListWorkflowEdges(c, repository.ListWorkflowEdgesParams{
+			WorkflowID: workflow.ID,
+			TenantID:   tenantID.(string),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		apiWorkflows = append(apiWorkflows, api.Workflow{
+			Id:            workflow.ID,
+			Name:          workflow.Name,
+			FailurePolicy: api.WorkflowFailurePolicy(workflow.FailurePolicy),
+			Edges:         toAPIWorkflowEdges(edges),
+			TenantId:      workflow.TenantID,
+			CreatedAt:     workflow.CreatedAt,
+			UpdatedAt:     workflow.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, apiWorkflows)
+}
+
+// ListWorkflowRuns godoc
+func (h *WorkflowHandler) ListWorkflowRuns(c *gin.Context, id types.UUID, params api.ListWorkflowRunsParams) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	_, err := h.store.GetWorkflowByID(c, repository.GetWorkflowByIDParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "workflow not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	pagingSql := helpers.GetPagingSQL(helpers.PagingRequest{
+		MaxPageSize:     50,
+		DefaultPage:     1,
+		DefaultPageSize: 10,
+		DefaultSortBy:   "started_at",
+		DefaultOrder:    "desc",
+		Page:            params.Page,
+		PageSize:        params.PageSize,
+	})
+
+	runs, err := h.store.ListWorkflowRuns(c, repository.ListWorkflowRunsParams{
+		WorkflowID: id,
+		TenantID:   tenantID.(string),
+		Limit:      pagingSql.PageSize,
+		Offset:     pagingSql.Offset,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiRuns := make([]api.WorkflowRun, 0, len(runs))
+	for _, run := range runs {
+		apiRuns = append(apiRuns, toAPIWorkflowRun(run))
+	}
+
+	c.JSON(http.StatusOK, apiRuns)
+}
+
+// GetWorkflowRun godoc
+func (h *WorkflowHandler) GetWorkflowRun(c *gin.Context, id types.UUID) {
+	tenantID, exists := c.Get(access.AUTH_TENANT_ID_KEY)
+	if !exists {
+		c.JSON(http.StatusInternalServerError, errors.New("TenantID not found"))
+		return
+	}
+
+	run, err := h.store.GetWorkflowRunByID(c, repository.GetWorkflowRunByIDParams{
+		ID:       id,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		if err.Error() == pgx.ErrNoRows.Error() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "workflow run not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	workflow, err := h.store.GetWorkflowByID(c, repository.GetWorkflowByIDParams{
+		ID:       run.WorkflowID,
+		TenantID: tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	nodes, err := h.store.ListWorkflowRunNodes(c, repository.ListWorkflowRunNodesParams{
+		WorkflowRunID: run.ID,
+		TenantID:      tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	edges, err := h.store.ListWorkflowEdges(c, repository.ListWorkflowEdgesParams{
+		WorkflowID: workflow.ID,
+		TenantID:   tenantID.(string),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiNodes := make([]api.WorkflowRunNode, 0, len(nodes))
+	for _, node := range nodes {
+		apiNodes = append(apiNodes, api.WorkflowRunNode{
+			JobName:    node.JobName,
+			Status:     api.WorkflowRunNodeStatus(node.Status),
+			AuditLogId: fromNullableUUID(node.AuditLogID),
+			UpdatedAt:  node.UpdatedAt,
+		})
+	}
+
+	apiRun := toAPIWorkflowRun(run)
+	c.JSON(http.StatusOK, api.WorkflowRunState{
+		Id:           apiRun.Id,
+		WorkflowId:   apiRun.WorkflowId,
+		WorkflowName: workflow.Name,
+		Status:       api.WorkflowRunStateStatus(apiRun.Status),
+		StartedAt:    apiRun.StartedAt,
+		EndedAt:      apiRun.EndedAt,
+		TenantId:     apiRun.TenantId,
+		Nodes:        apiNodes,
+		Edges:        toAPIWorkflowEdges(edges),
+	})
+}
+
+// toAPIWorkflowRun converts a workflow run to its API response format
+func toAPIWorkflowRun(run repository.CronWorkflowRun) api.WorkflowRun {
+	apiRun := api.WorkflowRun{
+		Id:         run.ID,
+		WorkflowId: run.WorkflowID,
+		Status:     api.WorkflowRunStatus(run.Status),
+		StartedAt:  run.StartedAt,
+		TenantId:   run.TenantID,
+	}
+	if run.EndedAt.Valid {
+		apiRun.EndedAt = &run.EndedAt.Time
+	}
+	return apiRun
+}
+
+// toAPIWorkflowEdges converts the edges of a workflow to their API response format
+func toAPIWorkflowEdges(edges []repository.CronWorkflowEdge) []api.WorkflowEdge {
+	apiEdges := make([]api.WorkflowEdge, 0, len(edges))
+	for _, edge := range edges {
+		apiEdges = append(apiEdges, api.WorkflowEdge{
+			UpstreamJob:   edge.UpstreamJob,
+			DownstreamJob: edge.DownstreamJob,
+		})
+	}
+	return apiEdges
+}
@@ -0,0 +1,2 @@
+-- Schedule set through the API, taking precedence over the schedule defined in code
+ALTER TABLE cron_registered_jobs ADD COLUMN schedule_override VARCHAR NULL;
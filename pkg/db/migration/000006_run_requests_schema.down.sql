@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cron_run_requests;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- cron_run_requests definition
+-- Manual runs requested through the API, claimed by an instance holding the job
+CREATE UNLOGGED TABLE cron_run_requests (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    job_name VARCHAR NOT NULL,
+    user_id varchar(128) NOT NULL,
+    claimed_by VARCHAR(128) NULL,
+    claimed_at TIMESTAMPTZ NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cron_run_requests_tenant_id ON cron_run_requests ("tenant_id");
+
+CREATE TRIGGER update_cron_run_requests_modtime
+BEFORE UPDATE ON cron_run_requests
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
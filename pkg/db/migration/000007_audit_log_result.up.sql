@@ -0,0 +1,2 @@
+-- Structured result reported by jobs implementing JobWithResult
+ALTER TABLE cron_job_audit_logs ADD COLUMN result JSONB NULL;
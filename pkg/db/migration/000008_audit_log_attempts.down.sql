@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_cron_job_audit_logs_parent_id;
+ALTER TABLE cron_job_audit_logs DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE cron_job_audit_logs DROP COLUMN IF EXISTS attempt;
\ No newline at end of file
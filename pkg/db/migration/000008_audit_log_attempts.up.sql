@@ -0,0 +1,4 @@
+-- Retries of a failed run are recorded as audit logs linked to the first attempt
+ALTER TABLE cron_job_audit_logs ADD COLUMN attempt INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE cron_job_audit_logs ADD COLUMN parent_id uuid NULL REFERENCES cron_job_audit_logs (id) ON DELETE SET NULL;
+CREATE INDEX idx_cron_job_audit_logs_parent_id ON cron_job_audit_logs ("parent_id");
@@ -0,0 +1 @@
+ALTER TABLE cron_registered_jobs DROP COLUMN IF EXISTS time_zone;
\ No newline at end of file
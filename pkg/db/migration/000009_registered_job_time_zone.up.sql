@@ -0,0 +1,2 @@
+-- Time zone of the schedule, defined in code. NULL uses the time zone of the server.
+ALTER TABLE cron_registered_jobs ADD COLUMN time_zone VARCHAR NULL;
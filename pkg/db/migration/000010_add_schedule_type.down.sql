@@ -0,0 +1 @@
+ALTER TABLE cron_registered_jobs DROP COLUMN IF EXISTS schedule_type;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Type of the schedule defined in code: 'cron' for cron expressions, 'rrule' for RFC 5545 recurrence rules
+ALTER TABLE cron_registered_jobs ADD COLUMN schedule_type VARCHAR NOT NULL DEFAULT 'cron';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS cron_holidays;
+DROP TABLE IF EXISTS cron_blackout_windows;
\ No newline at end of file
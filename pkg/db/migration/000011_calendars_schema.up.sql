@@ -0,0 +1,38 @@
+-- cron_blackout_windows definition
+-- Maintenance windows during which the runs of the jobs of a tenant are suppressed
+CREATE TABLE cron_blackout_windows (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT cron_blackout_windows_range CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_cron_blackout_windows_tenant_time ON cron_blackout_windows (tenant_id, start_time, end_time);
+
+CREATE TRIGGER update_cron_blackout_windows_modtime
+BEFORE UPDATE ON cron_blackout_windows
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
+
+-- cron_holidays definition
+-- Public holidays of a tenant, during which the runs of its jobs are suppressed.
+-- A holiday covers the whole day in the time zone of each job.
+CREATE TABLE cron_holidays (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    holiday_date DATE NOT NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE cron_holidays ADD CONSTRAINT cron_holidays_uniq UNIQUE (tenant_id, holiday_date);
+
+CREATE TRIGGER update_cron_holidays_modtime
+BEFORE UPDATE ON cron_holidays
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
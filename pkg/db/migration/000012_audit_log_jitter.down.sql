@@ -0,0 +1 @@
+ALTER TABLE cron_job_audit_logs DROP COLUMN IF EXISTS jitter_ms;
\ No newline at end of file
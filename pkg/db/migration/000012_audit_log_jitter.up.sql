@@ -0,0 +1,2 @@
+-- Random delay applied before a run starts, for jobs with a maximum jitter
+ALTER TABLE cron_job_audit_logs ADD COLUMN jitter_ms INTEGER NULL;
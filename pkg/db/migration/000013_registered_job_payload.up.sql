@@ -0,0 +1,2 @@
+-- Configuration of the job set through the API, delivered to the job at run time
+ALTER TABLE cron_registered_jobs ADD COLUMN payload JSONB NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cron_one_off_jobs;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- cron_one_off_jobs definition
+-- Jobs run once at a given time, claimed by any instance knowing their job type
+CREATE TABLE cron_one_off_jobs (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    job_type VARCHAR NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    payload JSONB NULL,
+    claimed_by VARCHAR(128) NULL,
+    claimed_at TIMESTAMPTZ NULL,
+    completed_at TIMESTAMPTZ NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cron_one_off_jobs_tenant_id ON cron_one_off_jobs ("tenant_id");
+CREATE INDEX idx_cron_one_off_jobs_due ON cron_one_off_jobs (run_at) WHERE completed_at IS NULL;
+
+CREATE TRIGGER update_cron_one_off_jobs_modtime
+BEFORE UPDATE ON cron_one_off_jobs
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
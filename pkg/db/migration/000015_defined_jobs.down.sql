@@ -0,0 +1,2 @@
+ALTER TABLE cron_registered_jobs DROP COLUMN IF EXISTS source;
+ALTER TABLE cron_registered_jobs DROP COLUMN IF EXISTS job_type;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Jobs defined through the API are built by the factory of their job type on every instance,
+-- jobs registered in code have no job type
+ALTER TABLE cron_registered_jobs ADD COLUMN job_type VARCHAR NULL;
+-- 'code' for jobs registered in code, 'api' for jobs defined through the API
+ALTER TABLE cron_registered_jobs ADD COLUMN source VARCHAR NOT NULL DEFAULT 'code';
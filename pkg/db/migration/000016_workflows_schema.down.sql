@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_cron_job_audit_logs_workflow_run_id;
+ALTER TABLE cron_job_audit_logs DROP COLUMN IF EXISTS workflow_run_id;
+ALTER TABLE cron_run_requests DROP COLUMN IF EXISTS workflow_run_id;
+DROP TABLE IF EXISTS cron_workflow_run_nodes;
+DROP TABLE IF EXISTS cron_workflow_runs;
+DROP TABLE IF EXISTS cron_workflow_edges;
+DROP TABLE IF EXISTS cron_workflows;
\ No newline at end of file
@@ -0,0 +1,72 @@
+-- cron_workflows definition
+-- Workflows run registered jobs of a tenant after each other, along the edges of a DAG
+CREATE TABLE cron_workflows (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    failure_policy VARCHAR NOT NULL DEFAULT 'stop',
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE cron_workflows ADD CONSTRAINT cron_workflows_uniq UNIQUE (tenant_id, name);
+
+CREATE TRIGGER update_cron_workflows_modtime
+BEFORE UPDATE ON cron_workflows
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
+
+-- cron_workflow_edges definition
+-- The downstream job runs after the upstream job
+CREATE TABLE cron_workflow_edges (
+    workflow_id uuid NOT NULL REFERENCES cron_workflows (id) ON DELETE CASCADE,
+    upstream_job VARCHAR NOT NULL,
+    downstream_job VARCHAR NOT NULL,
+    tenant_id varchar(64) NOT NULL,
+    PRIMARY KEY (workflow_id, upstream_job, downstream_job)
+);
+
+CREATE INDEX idx_cron_workflow_edges_upstream_job ON cron_workflow_edges ("tenant_id", "upstream_job");
+
+-- cron_workflow_runs definition
+CREATE TABLE cron_workflow_runs (
+    id uuid NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    workflow_id uuid NOT NULL REFERENCES cron_workflows (id) ON DELETE CASCADE,
+    status VARCHAR NOT NULL DEFAULT 'running',
+    started_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at timestamptz NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_cron_workflow_runs_workflow_id ON cron_workflow_runs ("workflow_id", "started_at");
+
+CREATE TRIGGER update_cron_workflow_runs_modtime
+BEFORE UPDATE ON cron_workflow_runs
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
+
+-- cron_workflow_run_nodes definition
+-- State of each job of a workflow run
+CREATE TABLE cron_workflow_run_nodes (
+    workflow_run_id uuid NOT NULL REFERENCES cron_workflow_runs (id) ON DELETE CASCADE,
+    job_name VARCHAR NOT NULL,
+    status VARCHAR NOT NULL DEFAULT 'pending',
+    audit_log_id uuid NULL,
+    tenant_id varchar(64) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (workflow_run_id, job_name)
+);
+
+CREATE TRIGGER update_cron_workflow_run_nodes_modtime
+BEFORE UPDATE ON cron_workflow_run_nodes
+FOR EACH ROW
+EXECUTE FUNCTION update_modified_column();
+
+-- Runs triggered by a workflow run, and the audit logs of its jobs
+ALTER TABLE cron_run_requests ADD COLUMN workflow_run_id uuid NULL;
+ALTER TABLE cron_job_audit_logs ADD COLUMN workflow_run_id uuid NULL;
+
+CREATE INDEX idx_cron_job_audit_logs_workflow_run_id ON cron_job_audit_logs ("workflow_run_id");
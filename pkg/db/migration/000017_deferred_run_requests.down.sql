@@ -0,0 +1,4 @@
+ALTER TABLE cron_run_requests SET UNLOGGED;
+DROP INDEX IF EXISTS idx_cron_run_requests_deferred;
+ALTER TABLE cron_run_requests DROP COLUMN IF EXISTS scheduled_time;
+ALTER TABLE cron_run_requests DROP COLUMN IF EXISTS run_after;
@@ -0,0 +1,11 @@
+-- Runs suppressed by a blackout window or holiday are deferred as run requests, claimed once run_after is reached
+ALTER TABLE cron_run_requests ADD COLUMN run_after TIMESTAMPTZ NULL;
+-- Fire time of the scheduled run a deferred run request belongs to
+ALTER TABLE cron_run_requests ADD COLUMN scheduled_time TIMESTAMPTZ NULL;
+
+-- A single deferred run is pending per job
+CREATE UNIQUE INDEX idx_cron_run_requests_deferred ON cron_run_requests ("tenant_id", "job_name")
+WHERE run_after IS NOT NULL AND claimed_by IS NULL;
+
+-- Deferred runs may wait for hours or days, they must survive a crash of the database
+ALTER TABLE cron_run_requests SET LOGGED;
@@ -0,0 +1 @@
+ALTER TABLE cron_registered_jobs SET UNLOGGED;
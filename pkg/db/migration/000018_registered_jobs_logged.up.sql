@@ -0,0 +1,3 @@
+-- Jobs defined through the API, payloads, schedule overrides and is_enabled flags only exist in
+-- cron_registered_jobs, they must survive a crash of the database
+ALTER TABLE cron_registered_jobs SET LOGGED;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_cron_run_requests_deferred;
+DELETE FROM cron_run_requests
+WHERE run_after IS NOT NULL AND claimed_by IS NULL AND workflow_run_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_cron_run_requests_deferred ON cron_run_requests ("tenant_id", "job_name")
+WHERE run_after IS NOT NULL AND claimed_by IS NULL;
@@ -0,0 +1,7 @@
+-- A single deferred run is pending per job and workflow run, so a run of a workflow job deferred by a
+-- blackout window or holiday is not merged into the deferred scheduled run of the job
+DROP INDEX IF EXISTS idx_cron_run_requests_deferred;
+CREATE UNIQUE INDEX idx_cron_run_requests_deferred ON cron_run_requests (
+  "tenant_id", "job_name", (COALESCE(workflow_run_id, '00000000-0000-0000-0000-000000000000'::uuid))
+)
+WHERE run_after IS NOT NULL AND claimed_by IS NULL;
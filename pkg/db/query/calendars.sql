@@ -0,0 +1,89 @@
+-- name: ListBlackoutWindows :many
+SELECT * 
+FROM cron_blackout_windows
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY start_time;
+
+-- name: GetBlackoutWindowByID :one
+SELECT * 
+FROM cron_blackout_windows
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: CreateBlackoutWindow :one
+INSERT INTO cron_blackout_windows (
+  name, start_time, end_time, tenant_id
+) VALUES (
+  sqlc.arg('name')::text,
+  sqlc.arg('start_time')::timestamptz,
+  sqlc.arg('end_time')::timestamptz,
+  sqlc.arg('tenant_id')::text
+)
+RETURNING *;
+
+-- name: UpdateBlackoutWindow :one
+UPDATE cron_blackout_windows
+SET name = sqlc.arg('name')::text,
+    start_time = sqlc.arg('start_time')::timestamptz,
+    end_time = sqlc.arg('end_time')::timestamptz,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text
+RETURNING *;
+
+-- name: DeleteBlackoutWindow :execresult
+DELETE FROM cron_blackout_windows
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- The blackout window containing a time, the one ending last when windows overlap
+-- name: GetActiveBlackoutWindow :one
+SELECT * 
+FROM cron_blackout_windows
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+  AND start_time <= sqlc.arg('at')::timestamptz
+  AND end_time > sqlc.arg('at')::timestamptz
+ORDER BY end_time DESC
+LIMIT 1;
+
+-- name: ListHolidays :many
+SELECT * 
+FROM cron_holidays
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY holiday_date;
+
+-- name: GetHolidayByID :one
+SELECT * 
+FROM cron_holidays
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: CreateHoliday :one
+INSERT INTO cron_holidays (
+  name, holiday_date, tenant_id
+) VALUES (
+  sqlc.arg('name')::text,
+  sqlc.arg('holiday_date')::date,
+  sqlc.arg('tenant_id')::text
+)
+RETURNING *;
+
+-- name: UpdateHoliday :one
+UPDATE cron_holidays
+SET name = sqlc.arg('name')::text,
+    holiday_date = sqlc.arg('holiday_date')::date,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text
+RETURNING *;
+
+-- name: DeleteHoliday :execresult
+DELETE FROM cron_holidays
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: GetHolidayByDate :one
+SELECT * 
+FROM cron_holidays
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+  AND holiday_date = sqlc.arg('holiday_date')::date;
@@ -39,7 +39,7 @@ OFFSET $2;
 
 -- name: CreateJobAuditLog :one
 INSERT INTO cron_job_audit_logs (
-  user_id, tenant_id, "app_id", "request_id", "job_name", "scheduled_time", "start_time", "end_time", "status", "output", "error"
+  user_id, tenant_id, "app_id", "request_id", "job_name", "scheduled_time", "start_time", "end_time", "status", "output", "error", "attempt", "parent_id", "workflow_run_id"
 ) VALUES (
   $1, sqlc.arg('tenant_id')::text, 
   $2, 
@@ -50,7 +50,10 @@ INSERT INTO cron_job_audit_logs (
   $7, 
   $8, 
   $9, 
-  $10
+  $10, 
+  $11, 
+  $12, 
+  $13
 )
 RETURNING *;
 
@@ -60,11 +63,25 @@ SET
     "end_time" =  COALESCE(sqlc.narg('end_time'), end_time),
     "status" =  sqlc.arg('status'),
     "output" =  COALESCE(sqlc.narg('output'), output),
-    "error" =  COALESCE(sqlc.narg('error'), error)
+    "error" =  COALESCE(sqlc.narg('error'), error),
+    "result" =  COALESCE(sqlc.narg('result'), result)
 
 WHERE id = $1 AND tenant_id = sqlc.arg('tenant_id')::text
 RETURNING *;
 
+-- Records the random delay applied before the run started
+-- name: UpdateJobAuditLogJitter :exec
+UPDATE cron_job_audit_logs
+SET "jitter_ms" = $2
+WHERE id = $1 AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- Links the audit logs of a run, including its retries, to the workflow run it started
+-- name: SetJobAuditLogWorkflowRun :exec
+UPDATE cron_job_audit_logs
+SET "workflow_run_id" = sqlc.arg('workflow_run_id')::uuid
+WHERE (id = sqlc.arg('id')::uuid OR parent_id = sqlc.arg('id')::uuid)
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
 -- name: DeleteJobAuditLog :one
 DELETE FROM cron_job_audit_logs
 WHERE id = $1 and tenant_id = sqlc.arg('tenant_id')::text
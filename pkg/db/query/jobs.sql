@@ -62,14 +62,16 @@ WHERE id = sqlc.arg('id')::uuid
 -- name: CleanupOldTasks :execresult
 DELETE FROM cron_jobs
 WHERE status IN ('completed', 'failed') 
-  AND updated_at < NOW() - INTERVAL '7 days'
+  AND updated_at < sqlc.arg('updated_before')::timestamptz
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
+-- Session-level lock: held until released or until the connection is closed
 -- name: TryAdvisoryLock :one
 SELECT pg_try_advisory_lock(sqlc.arg('lock_id')::bigint) as lock_acquired;
 
--- name: ReleaseAdvisoryLock :exec
-SELECT pg_advisory_unlock(sqlc.arg('lock_id')::bigint);
+-- Session-level lock: must run on the connection that acquired it
+-- name: ReleaseAdvisoryLock :one
+SELECT pg_advisory_unlock(sqlc.arg('lock_id')::bigint) as lock_released;
 
 -- name: UpdateJobStatusToFailed :exec
 UPDATE cron_jobs 
@@ -110,7 +112,7 @@ SET
   locked_at = sqlc.arg('now')::timestamptz,
   updated_at = sqlc.arg('now')::timestamptz
 WHERE cron_jobs.locked_at IS NULL 
-   OR cron_jobs.locked_at < sqlc.arg('now')::timestamptz - INTERVAL '10 minutes'
+   OR cron_jobs.locked_at < sqlc.arg('stale_before')::timestamptz
    OR cron_jobs.status != 'running'
 RETURNING id;
 
@@ -122,7 +124,7 @@ SET status = 'failed',
     locked_at = NULL,
     updated_at = NOW()
 WHERE status = 'running' 
-  AND locked_at < NOW() - INTERVAL '15 minutes'
+  AND locked_at < sqlc.arg('locked_before')::timestamptz
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
 -- NEW: Get jobs that are potentially stuck
@@ -130,7 +132,7 @@ WHERE status = 'running'
 SELECT id, "lock", job_name, tenant_id, locked_by, locked_at, status
 FROM cron_jobs
 WHERE status = 'running' 
-  AND locked_at < NOW() - INTERVAL '15 minutes'
+  AND locked_at < sqlc.arg('locked_before')::timestamptz
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
 -- NEW: Force unlock a specific job (for admin operations)
@@ -148,7 +150,7 @@ WHERE id = sqlc.arg('job_id')::uuid
 SELECT 
   id,
   CASE 
-    WHEN status = 'running' AND locked_at > NOW() - INTERVAL '10 minutes' THEN true
+    WHEN status = 'running' AND locked_at > sqlc.arg('locked_after')::timestamptz THEN true
     ELSE false
   END as is_locked,
   locked_by,
@@ -165,4 +167,17 @@ SET locked_at = NOW(),
     updated_at = NOW()
 WHERE id = sqlc.arg('job_id')::uuid
   AND locked_by = sqlc.arg('instance_id')::text
-  AND status = 'running';
\ No newline at end of file
+  AND status = 'running';
+-- name: GetJobByLock :one
+SELECT * FROM cron_jobs
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+  AND lock = sqlc.arg('lock')::text
+LIMIT 1;
+
+-- Compare-and-set of next_execution_time, so that a single instance catches up missed runs
+-- name: ClaimMissedRuns :execresult
+UPDATE cron_jobs
+SET next_execution_time = sqlc.arg('next_execution_time')::timestamptz
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+  AND lock = sqlc.arg('lock')::text
+  AND next_execution_time = sqlc.arg('expected_next_execution_time')::timestamptz;
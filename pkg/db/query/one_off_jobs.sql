@@ -0,0 +1,71 @@
+-- name: CreateOneOffJob :one
+INSERT INTO cron_one_off_jobs (
+  job_type, run_at, payload, tenant_id
+) VALUES (
+  sqlc.arg('job_type')::text,
+  sqlc.arg('run_at')::timestamptz,
+  sqlc.narg('payload')::jsonb,
+  sqlc.arg('tenant_id')::text
+)
+RETURNING *;
+
+-- Claims the due one-off jobs of the given types. Jobs claimed by another instance are skipped,
+-- unless the claim is stale, e.g. because the instance stopped during the run.
+-- name: ClaimDueOneOffJobs :many
+UPDATE cron_one_off_jobs
+SET claimed_by = sqlc.arg('instance_id')::text,
+    claimed_at = NOW(),
+    updated_at = NOW()
+WHERE id IN (
+  SELECT id
+  FROM cron_one_off_jobs
+  WHERE completed_at IS NULL
+    AND run_at <= NOW()
+    AND job_type = ANY(sqlc.arg('job_types')::text[])
+    AND (claimed_by IS NULL OR claimed_at < sqlc.arg('claimed_before')::timestamptz)
+  ORDER BY run_at
+  LIMIT sqlc.arg('limit')::int
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteOneOffJob :exec
+UPDATE cron_one_off_jobs
+SET completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND claimed_by = sqlc.arg('instance_id')::text;
+
+-- name: CleanupOneOffJobs :execresult
+DELETE FROM cron_one_off_jobs
+WHERE completed_at < sqlc.arg('completed_before')::timestamptz;
+
+-- Releases the claim of a one-off job and moves it to run_at, e.g. to the end of a blackout window
+-- name: DeferOneOffJob :exec
+UPDATE cron_one_off_jobs
+SET run_at = sqlc.arg('run_at')::timestamptz,
+    claimed_by = NULL,
+    claimed_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND claimed_by = sqlc.arg('instance_id')::text
+  AND completed_at IS NULL;
+
+-- Keeps the claim of a one-off job from going stale while it runs
+-- name: HeartbeatOneOffJob :exec
+UPDATE cron_one_off_jobs
+SET claimed_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND claimed_by = sqlc.arg('instance_id')::text
+  AND completed_at IS NULL;
+
+-- Releases the claim of a one-off job whose run did not happen, so it is claimed again
+-- name: ReleaseOneOffJob :exec
+UPDATE cron_one_off_jobs
+SET claimed_by = NULL,
+    claimed_at = NULL,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND claimed_by = sqlc.arg('instance_id')::text
+  AND completed_at IS NULL;
@@ -1,8 +1,10 @@
 
+-- is_enabled is only set on insert so that re-registration keeps the operator's choice.
+-- Jobs defined through the API are never overwritten by a job registered in code.
 -- name: UpsertRegisteredJob :one
 INSERT INTO cron_registered_jobs (
   job_name, schedule, is_long_running, is_enabled, 
-  last_registered_at, instance_id, tenant_id
+  last_registered_at, instance_id, tenant_id, time_zone, schedule_type
 ) VALUES (
   sqlc.arg('job_name')::text,
   sqlc.arg('schedule')::text,
@@ -10,20 +12,24 @@ INSERT INTO cron_registered_jobs (
   sqlc.arg('is_enabled')::boolean,
   NOW(),
   sqlc.arg('instance_id')::text,
-  sqlc.arg('tenant_id')::text
+  sqlc.arg('tenant_id')::text,
+  sqlc.narg('time_zone')::text,
+  sqlc.arg('schedule_type')::text
 )
 ON CONFLICT (tenant_id, job_name) DO UPDATE
 SET 
   schedule = EXCLUDED.schedule,
   is_long_running = EXCLUDED.is_long_running,
-  is_enabled = EXCLUDED.is_enabled,
+  time_zone = EXCLUDED.time_zone,
+  schedule_type = EXCLUDED.schedule_type,
   last_registered_at = NOW(),
   instance_id = EXCLUDED.instance_id,
   updated_at = NOW()
+WHERE cron_registered_jobs.source = 'code'
 RETURNING *;
 
 -- name: ListRegisteredJobs :many
-SELECT rj.*, 
+SELECT sqlc.embed(rj), 
   (SELECT COUNT(*) FROM cron_job_audit_logs al 
    WHERE al.job_name = rj.job_name AND al.tenant_id = rj.tenant_id) as execution_count
 FROM cron_registered_jobs rj
@@ -53,6 +59,12 @@ FROM cron_registered_jobs
 WHERE id = sqlc.arg('id')::uuid
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
+-- name: GetRegisteredJobByName :one
+SELECT * 
+FROM cron_registered_jobs
+WHERE job_name = sqlc.arg('job_name')::text
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
 -- name: UpdateRegisteredJobEnabled :execresult
 UPDATE cron_registered_jobs
 SET is_enabled = sqlc.arg('is_enabled')::boolean,
@@ -60,6 +72,27 @@ SET is_enabled = sqlc.arg('is_enabled')::boolean,
 WHERE id = sqlc.arg('id')::uuid
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
+-- A NULL payload removes the payload of the job
+-- name: UpdateRegisteredJobPayload :execresult
+UPDATE cron_registered_jobs
+SET payload = sqlc.narg('payload')::jsonb,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- A NULL schedule_override reverts the job to the schedule defined in code
+-- name: UpdateRegisteredJobScheduleOverride :execresult
+UPDATE cron_registered_jobs
+SET schedule_override = sqlc.narg('schedule_override')::text,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: ListRegisteredJobsByTenants :many
+SELECT * 
+FROM cron_registered_jobs
+WHERE tenant_id = ANY(sqlc.arg('tenant_ids')::text[]);
+
 -- name: ListJobAuditLogsByJobName :many
 SELECT * 
 FROM cron_job_audit_logs
@@ -83,12 +116,54 @@ FROM cron_job_audit_logs
 WHERE job_name = sqlc.arg('job_name')::text
   AND tenant_id = sqlc.arg('tenant_id')::text;
 
+-- Keep registrations of jobs held by a running instance from being cleaned up
+-- name: TouchRegisteredJobs :execresult
+UPDATE cron_registered_jobs rj
+SET last_registered_at = NOW()
+FROM UNNEST(sqlc.arg('tenant_ids')::text[], sqlc.arg('job_names')::text[]) AS j(tenant_id, job_name)
+WHERE rj.tenant_id = j.tenant_id
+  AND rj.job_name = j.job_name;
+
+-- Jobs defined through the API are not refreshed by instances, and are only removed through the API
 -- name: CleanupStaleRegisteredJobs :execresult
 DELETE FROM cron_registered_jobs
-WHERE last_registered_at < NOW() - INTERVAL '24 hours'
-  AND tenant_id = sqlc.arg('tenant_id')::text;
+WHERE last_registered_at < sqlc.arg('registered_before')::timestamptz
+  AND tenant_id = sqlc.arg('tenant_id')::text
+  AND source = 'code';
 
 -- name: DeleteRegisteredJob :exec
 DELETE FROM cron_registered_jobs
 WHERE job_name = sqlc.arg('job_name')::text
   AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- Jobs defined through the API are not held by an instance
+-- name: CreateDefinedJob :one
+INSERT INTO cron_registered_jobs (
+  job_name, job_type, schedule, schedule_type, payload, is_enabled,
+  last_registered_at, instance_id, tenant_id, source
+) VALUES (
+  sqlc.arg('job_name')::text,
+  sqlc.arg('job_type')::text,
+  sqlc.arg('schedule')::text,
+  sqlc.arg('schedule_type')::text,
+  sqlc.narg('payload')::jsonb,
+  sqlc.arg('is_enabled')::boolean,
+  NOW(),
+  '',
+  sqlc.arg('tenant_id')::text,
+  'api'
+)
+RETURNING *;
+
+-- Jobs defined through the API with one of the given job types, of all tenants
+-- name: ListDefinedJobs :many
+SELECT * 
+FROM cron_registered_jobs
+WHERE source = 'api'
+  AND job_type = ANY(sqlc.arg('job_types')::text[]);
+
+-- name: DeleteDefinedJob :execresult
+DELETE FROM cron_registered_jobs
+WHERE id = sqlc.arg('id')::uuid
+  AND tenant_id = sqlc.arg('tenant_id')::text
+  AND source = 'api';
@@ -0,0 +1,60 @@
+-- name: CreateRunRequest :one
+INSERT INTO cron_run_requests (
+  job_name, user_id, tenant_id, workflow_run_id
+) VALUES (
+  sqlc.arg('job_name')::text,
+  sqlc.arg('user_id')::text,
+  sqlc.arg('tenant_id')::text,
+  sqlc.narg('workflow_run_id')::uuid
+)
+RETURNING *;
+
+-- Defers a scheduled run of a job to run_after, part of a workflow run unless workflow_run_id is null.
+-- A single deferred run is pending per job and workflow run, so the runs suppressed by one blackout
+-- window or holiday are deferred once. A pending deferred run that expired without being claimed is replaced.
+-- name: CreateDeferredRunRequest :execresult
+INSERT INTO cron_run_requests (
+  job_name, user_id, tenant_id, run_after, scheduled_time, workflow_run_id
+) VALUES (
+  sqlc.arg('job_name')::text,
+  sqlc.arg('user_id')::text,
+  sqlc.arg('tenant_id')::text,
+  sqlc.arg('run_after')::timestamptz,
+  sqlc.arg('scheduled_time')::timestamptz,
+  sqlc.narg('workflow_run_id')::uuid
+)
+ON CONFLICT (tenant_id, job_name, (COALESCE(workflow_run_id, '00000000-0000-0000-0000-000000000000'::uuid))) WHERE run_after IS NOT NULL AND claimed_by IS NULL DO UPDATE
+SET run_after = EXCLUDED.run_after,
+    scheduled_time = EXCLUDED.scheduled_time,
+    updated_at = NOW()
+WHERE cron_run_requests.run_after < sqlc.arg('expired_before')::timestamptz;
+
+-- Only one instance can claim a run request, deferred run requests once they are due
+-- name: ClaimRunRequest :one
+UPDATE cron_run_requests
+SET claimed_by = sqlc.arg('instance_id')::text,
+    claimed_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND claimed_by IS NULL
+  AND (run_after IS NULL OR run_after <= NOW())
+RETURNING *;
+
+-- Pending run requests, used to pick up requests whose notification was missed and deferred runs.
+-- Requests are due when created, deferred runs at run_after.
+-- name: ListPendingRunRequests :many
+SELECT * 
+FROM cron_run_requests
+WHERE claimed_by IS NULL
+  AND tenant_id = ANY(sqlc.arg('tenant_ids')::text[])
+  AND COALESCE(run_after, created_at) > sqlc.arg('due_after')::timestamptz
+  AND COALESCE(run_after, created_at) <= NOW()
+ORDER BY COALESCE(run_after, created_at);
+
+-- Deferred runs are kept until they are long due
+-- name: CleanupRunRequests :execresult
+DELETE FROM cron_run_requests
+WHERE COALESCE(run_after, created_at) < sqlc.arg('due_before')::timestamptz;
+
+-- name: NotifyChannel :exec
+SELECT pg_notify(sqlc.arg('channel')::text, sqlc.arg('payload')::text);
@@ -0,0 +1,162 @@
+-- name: UpsertWorkflow :one
+INSERT INTO cron_workflows (
+  name, failure_policy, tenant_id
+) VALUES (
+  sqlc.arg('name')::text,
+  sqlc.arg('failure_policy')::text,
+  sqlc.arg('tenant_id')::text
+)
+ON CONFLICT (tenant_id, name) DO UPDATE SET
+  failure_policy = EXCLUDED.failure_policy,
+  updated_at = NOW()
+RETURNING *;
+
+-- name: GetWorkflowByID :one
+SELECT * FROM cron_workflows
+WHERE id = sqlc.arg('id')::uuid AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: ListWorkflows :many
+SELECT * FROM cron_workflows
+WHERE tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY name;
+
+-- Workflows started by the job, which is upstream of some job and downstream of none
+-- name: ListRootWorkflows :many
+SELECT w.id, w.name, w.failure_policy, w.tenant_id, w.created_at, w.updated_at
+FROM cron_workflows w
+WHERE w.tenant_id = sqlc.arg('tenant_id')::text
+  AND EXISTS (
+    SELECT 1 FROM cron_workflow_edges e
+    WHERE e.workflow_id = w.id AND e.upstream_job = sqlc.arg('job_name')::text
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM cron_workflow_edges e
+    WHERE e.workflow_id = w.id AND e.downstream_job = sqlc.arg('job_name')::text
+  );
+
+-- name: DeleteWorkflowEdges :exec
+DELETE FROM cron_workflow_edges
+WHERE workflow_id = sqlc.arg('workflow_id')::uuid;
+
+-- name: CreateWorkflowEdge :exec
+INSERT INTO cron_workflow_edges (
+  workflow_id, upstream_job, downstream_job, tenant_id
+) VALUES (
+  sqlc.arg('workflow_id')::uuid,
+  sqlc.arg('upstream_job')::text,
+  sqlc.arg('downstream_job')::text,
+  sqlc.arg('tenant_id')::text
+);
+
+-- name: ListWorkflowEdges :many
+SELECT * FROM cron_workflow_edges
+WHERE workflow_id = sqlc.arg('workflow_id')::uuid AND tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY upstream_job, downstream_job;
+
+-- name: CreateWorkflowRun :one
+INSERT INTO cron_workflow_runs (
+  workflow_id, tenant_id
+) VALUES (
+  sqlc.arg('workflow_id')::uuid,
+  sqlc.arg('tenant_id')::text
+)
+RETURNING *;
+
+-- name: GetWorkflowRunByID :one
+SELECT * FROM cron_workflow_runs
+WHERE id = sqlc.arg('id')::uuid AND tenant_id = sqlc.arg('tenant_id')::text;
+
+-- name: ListWorkflowRuns :many
+SELECT * FROM cron_workflow_runs
+WHERE workflow_id = sqlc.arg('workflow_id')::uuid AND tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY started_at DESC
+LIMIT sqlc.arg('limit')::int
+OFFSET sqlc.arg('offset')::int;
+
+-- Only the first instance finishing the last job of a workflow run completes it
+-- name: CompleteWorkflowRun :execresult
+UPDATE cron_workflow_runs
+SET status = sqlc.arg('status')::text,
+    ended_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')::uuid
+  AND status = 'running';
+
+-- name: CleanupWorkflowRuns :execresult
+DELETE FROM cron_workflow_runs
+WHERE started_at < sqlc.arg('started_before')::timestamptz;
+
+-- Creates a pending node for each job of the workflow
+-- name: CreateWorkflowRunNodes :exec
+INSERT INTO cron_workflow_run_nodes (
+  workflow_run_id, job_name, tenant_id
+)
+SELECT sqlc.arg('workflow_run_id')::uuid, jobs.job_name, sqlc.arg('tenant_id')::text
+FROM (
+  SELECT upstream_job AS job_name FROM cron_workflow_edges WHERE workflow_id = sqlc.arg('workflow_id')::uuid
+  UNION
+  SELECT downstream_job FROM cron_workflow_edges WHERE workflow_id = sqlc.arg('workflow_id')::uuid
+) jobs;
+
+-- name: ListWorkflowRunNodes :many
+SELECT * FROM cron_workflow_run_nodes
+WHERE workflow_run_id = sqlc.arg('workflow_run_id')::uuid AND tenant_id = sqlc.arg('tenant_id')::text
+ORDER BY job_name;
+
+-- Jobs that already finished are left as is, e.g. when failed as stuck before their run ended
+-- name: UpdateWorkflowRunNode :execresult
+UPDATE cron_workflow_run_nodes
+SET status = sqlc.arg('status')::text,
+    audit_log_id = COALESCE(sqlc.narg('audit_log_id')::uuid, audit_log_id),
+    updated_at = NOW()
+WHERE workflow_run_id = sqlc.arg('workflow_run_id')::uuid
+  AND job_name = sqlc.arg('job_name')::text
+  AND status IN ('pending', 'running');
+
+-- Only one instance can trigger a pending node
+-- name: ClaimWorkflowRunNode :execresult
+UPDATE cron_workflow_run_nodes
+SET status = 'running',
+    updated_at = NOW()
+WHERE workflow_run_id = sqlc.arg('workflow_run_id')::uuid
+  AND job_name = sqlc.arg('job_name')::text
+  AND status = 'pending';
+
+-- Skips the jobs not triggered yet, when a job failed in a workflow stopping on failure
+-- name: SkipPendingWorkflowRunNodes :exec
+UPDATE cron_workflow_run_nodes
+SET status = 'skipped',
+    updated_at = NOW()
+WHERE workflow_run_id = sqlc.arg('workflow_run_id')::uuid
+  AND status = 'pending';
+
+-- Fails the triggered jobs of running workflow runs whose run never finished: their run request,
+-- deferred or not, expired without being claimed, e.g. because no instance holds the job, or the run claiming it
+-- stopped without finishing, e.g. because its instance crashed
+-- name: FailStuckWorkflowRunNodes :many
+UPDATE cron_workflow_run_nodes n
+SET status = 'failed',
+    updated_at = NOW()
+FROM cron_workflow_runs wr
+WHERE wr.id = n.workflow_run_id
+  AND wr.status = 'running'
+  AND n.status = 'running'
+  AND n.tenant_id = ANY(sqlc.arg('tenant_ids')::text[])
+  AND n.updated_at < sqlc.arg('expired_before')::timestamptz
+  AND NOT EXISTS (
+    SELECT 1 FROM cron_run_requests r
+    WHERE r.workflow_run_id = n.workflow_run_id
+      AND r.job_name = n.job_name
+      AND (
+        (r.claimed_by IS NULL AND COALESCE(r.run_after, r.created_at) >= sqlc.arg('expired_before')::timestamptz)
+        OR r.claimed_at >= sqlc.arg('stale_before')::timestamptz
+      )
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM cron_jobs j
+    WHERE j.tenant_id = n.tenant_id
+      AND j.job_name = n.job_name
+      AND j.status = 'running'
+      AND j.locked_at >= sqlc.arg('stale_before')::timestamptz
+  )
+RETURNING n.workflow_run_id, n.job_name, n.tenant_id;
@@ -7,6 +7,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -14,9 +15,9 @@ import (
 
 const createJobAuditLog = `-- name: CreateJobAuditLog :one
 INSERT INTO cron_job_audit_logs (
-  user_id, tenant_id, "app_id", "request_id", "job_name", "scheduled_time", "start_time", "end_time", "status", "output", "error"
+  user_id, tenant_id, "app_id", "request_id", "job_name", "scheduled_time", "start_time", "end_time", "status", "output", "error", "attempt", "parent_id", "workflow_run_id"
 ) VALUES (
-  $1, $11::text, 
+  $1, $14::text, 
   $2, 
   $3, 
   $4, 
@@ -25,9 +26,12 @@ INSERT INTO cron_job_audit_logs (
   $7, 
   $8, 
   $9, 
-  $10
+  $10, 
+  $11, 
+  $12, 
+  $13
 )
-RETURNING id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at
+RETURNING id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at, result, attempt, parent_id, jitter_ms, workflow_run_id
 `
 
 type CreateJobAuditLogParams struct {
@@ -41,6 +45,9 @@ type CreateJobAuditLogParams struct {
 	Status        string           `json:"status"`
 	Output        pgtype.Text      `json:"output"`
 	Error         pgtype.Text      `json:"error"`
+	Attempt       int32            `json:"attempt"`
+	ParentID      pgtype.UUID      `json:"parent_id"`
+	WorkflowRunID pgtype.UUID      `json:"workflow_run_id"`
 	TenantID      string           `json:"tenant_id"`
 }
 
@@ -56,6 +63,9 @@ func (q *Queries) CreateJobAuditLog(ctx context.Context, arg CreateJobAuditLogPa
 		arg.Status,
 		arg.Output,
 		arg.Error,
+		arg.Attempt,
+		arg.ParentID,
+		arg.WorkflowRunID,
 		arg.TenantID,
 	)
 	var i CronJobAuditLog
@@ -74,6 +84,11 @@ func (q *Queries) CreateJobAuditLog(ctx context.Context, arg CreateJobAuditLogPa
 		&i.TenantID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Result,
+		&i.Attempt,
+		&i.ParentID,
+		&i.JitterMs,
+		&i.WorkflowRunID,
 	)
 	return i, err
 }
@@ -97,7 +112,7 @@ func (q *Queries) DeleteJobAuditLog(ctx context.Context, arg DeleteJobAuditLogPa
 }
 
 const getJobAuditLogByID = `-- name: GetJobAuditLogByID :one
-SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at FROM cron_job_audit_logs
+SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at, result, attempt, parent_id, jitter_ms, workflow_run_id FROM cron_job_audit_logs
 WHERE id = $1 AND tenant_id = $2::text LIMIT 1
 `
 
@@ -124,12 +139,17 @@ func (q *Queries) GetJobAuditLogByID(ctx context.Context, arg GetJobAuditLogByID
 		&i.TenantID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Result,
+		&i.Attempt,
+		&i.ParentID,
+		&i.JitterMs,
+		&i.WorkflowRunID,
 	)
 	return i, err
 }
 
 const listJobAuditLogs = `-- name: ListJobAuditLogs :many
-SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at FROM cron_job_audit_logs
+SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at, result, attempt, parent_id, jitter_ms, workflow_run_id FROM cron_job_audit_logs
 WHERE tenant_id = $3::text
   AND (UPPER(job_name) LIKE UPPER($4) OR $4 IS NULL)
 ORDER BY
@@ -204,6 +224,11 @@ func (q *Queries) ListJobAuditLogs(ctx context.Context, arg ListJobAuditLogsPara
 			&i.TenantID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Result,
+			&i.Attempt,
+			&i.ParentID,
+			&i.JitterMs,
+			&i.WorkflowRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -215,16 +240,36 @@ func (q *Queries) ListJobAuditLogs(ctx context.Context, arg ListJobAuditLogsPara
 	return items, nil
 }
 
+const setJobAuditLogWorkflowRun = `-- name: SetJobAuditLogWorkflowRun :exec
+UPDATE cron_job_audit_logs
+SET "workflow_run_id" = $1::uuid
+WHERE (id = $2::uuid OR parent_id = $2::uuid)
+  AND tenant_id = $3::text
+`
+
+type SetJobAuditLogWorkflowRunParams struct {
+	WorkflowRunID uuid.UUID `json:"workflow_run_id"`
+	ID            uuid.UUID `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+}
+
+// Links the audit logs of a run, including its retries, to the workflow run it started
+func (q *Queries) SetJobAuditLogWorkflowRun(ctx context.Context, arg SetJobAuditLogWorkflowRunParams) error {
+	_, err := q.db.Exec(ctx, setJobAuditLogWorkflowRun, arg.WorkflowRunID, arg.ID, arg.TenantID)
+	return err
+}
+
 const updateJobAuditLog = `-- name: UpdateJobAuditLog :one
 UPDATE cron_job_audit_logs 
 SET 
     "end_time" =  COALESCE($2, end_time),
     "status" =  $3,
     "output" =  COALESCE($4, output),
-    "error" =  COALESCE($5, error)
+    "error" =  COALESCE($5, error),
+    "result" =  COALESCE($6, result)
 
-WHERE id = $1 AND tenant_id = $6::text
-RETURNING id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at
+WHERE id = $1 AND tenant_id = $7::text
+RETURNING id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at, result, attempt, parent_id, jitter_ms, workflow_run_id
 `
 
 type UpdateJobAuditLogParams struct {
@@ -233,6 +278,7 @@ type UpdateJobAuditLogParams struct {
 	Status   string           `json:"status"`
 	Output   pgtype.Text      `json:"output"`
 	Error    pgtype.Text      `json:"error"`
+	Result   json.RawMessage  `json:"result"`
 	TenantID string           `json:"tenant_id"`
 }
 
@@ -243,6 +289,7 @@ func (q *Queries) UpdateJobAuditLog(ctx context.Context, arg UpdateJobAuditLogPa
 		arg.Status,
 		arg.Output,
 		arg.Error,
+		arg.Result,
 		arg.TenantID,
 	)
 	var i CronJobAuditLog
@@ -261,6 +308,29 @@ func (q *Queries) UpdateJobAuditLog(ctx context.Context, arg UpdateJobAuditLogPa
 		&i.TenantID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Result,
+		&i.Attempt,
+		&i.ParentID,
+		&i.JitterMs,
+		&i.WorkflowRunID,
 	)
 	return i, err
 }
+
+const updateJobAuditLogJitter = `-- name: UpdateJobAuditLogJitter :exec
+UPDATE cron_job_audit_logs
+SET "jitter_ms" = $2
+WHERE id = $1 AND tenant_id = $3::text
+`
+
+type UpdateJobAuditLogJitterParams struct {
+	ID       uuid.UUID   `json:"id"`
+	JitterMs pgtype.Int4 `json:"jitter_ms"`
+	TenantID string      `json:"tenant_id"`
+}
+
+// Records the random delay applied before the run started
+func (q *Queries) UpdateJobAuditLogJitter(ctx context.Context, arg UpdateJobAuditLogJitterParams) error {
+	_, err := q.db.Exec(ctx, updateJobAuditLogJitter, arg.ID, arg.JitterMs, arg.TenantID)
+	return err
+}
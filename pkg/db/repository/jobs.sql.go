@@ -37,7 +37,7 @@ SET
   locked_at = $4::timestamptz,
   updated_at = $4::timestamptz
 WHERE cron_jobs.locked_at IS NULL 
-   OR cron_jobs.locked_at < $4::timestamptz - INTERVAL '10 minutes'
+   OR cron_jobs.locked_at < $7::timestamptz
    OR cron_jobs.status != 'running'
 RETURNING id
 `
@@ -49,6 +49,7 @@ type AcquireJobLockInDBParams struct {
 	Now         time.Time `json:"now"`
 	NextRunTime time.Time `json:"next_run_time"`
 	InstanceID  string    `json:"instance_id"`
+	StaleBefore time.Time `json:"stale_before"`
 }
 
 func (q *Queries) AcquireJobLockInDB(ctx context.Context, arg AcquireJobLockInDBParams) (uuid.UUID, error) {
@@ -59,21 +60,52 @@ func (q *Queries) AcquireJobLockInDB(ctx context.Context, arg AcquireJobLockInDB
 		arg.Now,
 		arg.NextRunTime,
 		arg.InstanceID,
+		arg.StaleBefore,
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const claimMissedRuns = `-- name: ClaimMissedRuns :execresult
+UPDATE cron_jobs
+SET next_execution_time = $1::timestamptz
+WHERE tenant_id = $2::text
+  AND lock = $3::text
+  AND next_execution_time = $4::timestamptz
+`
+
+type ClaimMissedRunsParams struct {
+	NextExecutionTime         time.Time `json:"next_execution_time"`
+	TenantID                  string    `json:"tenant_id"`
+	Lock                      string    `json:"lock"`
+	ExpectedNextExecutionTime time.Time `json:"expected_next_execution_time"`
+}
+
+// Compare-and-set of next_execution_time, so that a single instance catches up missed runs
+func (q *Queries) ClaimMissedRuns(ctx context.Context, arg ClaimMissedRunsParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, claimMissedRuns,
+		arg.NextExecutionTime,
+		arg.TenantID,
+		arg.Lock,
+		arg.ExpectedNextExecutionTime,
+	)
+}
+
 const cleanupOldTasks = `-- name: CleanupOldTasks :execresult
 DELETE FROM cron_jobs
 WHERE status IN ('completed', 'failed') 
-  AND updated_at < NOW() - INTERVAL '7 days'
-  AND tenant_id = $1::text
+  AND updated_at < $1::timestamptz
+  AND tenant_id = $2::text
 `
 
-func (q *Queries) CleanupOldTasks(ctx context.Context, tenantID string) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, cleanupOldTasks, tenantID)
+type CleanupOldTasksParams struct {
+	UpdatedBefore time.Time `json:"updated_before"`
+	TenantID      string    `json:"tenant_id"`
+}
+
+func (q *Queries) CleanupOldTasks(ctx context.Context, arg CleanupOldTasksParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, cleanupOldTasks, arg.UpdatedBefore, arg.TenantID)
 }
 
 const cleanupStaleLocks = `-- name: CleanupStaleLocks :execresult
@@ -83,13 +115,18 @@ SET status = 'failed',
     locked_at = NULL,
     updated_at = NOW()
 WHERE status = 'running' 
-  AND locked_at < NOW() - INTERVAL '15 minutes'
-  AND tenant_id = $1::text
+  AND locked_at < $1::timestamptz
+  AND tenant_id = $2::text
 `
 
+type CleanupStaleLocksParams struct {
+	LockedBefore time.Time `json:"locked_before"`
+	TenantID     string    `json:"tenant_id"`
+}
+
 // NEW: Clean up stale locks from crashed instances
-func (q *Queries) CleanupStaleLocks(ctx context.Context, tenantID string) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, cleanupStaleLocks, tenantID)
+func (q *Queries) CleanupStaleLocks(ctx context.Context, arg CleanupStaleLocksParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, cleanupStaleLocks, arg.LockedBefore, arg.TenantID)
 }
 
 const createJob = `-- name: CreateJob :one
@@ -206,14 +243,50 @@ func (q *Queries) GetJobByID(ctx context.Context, arg GetJobByIDParams) (CronJob
 	return i, err
 }
 
+const getJobByLock = `-- name: GetJobByLock :one
+SELECT id, lock, job_name, status, last_execution_time, next_execution_time, locked_by, locked_at, tenant_id, created_at, updated_at FROM cron_jobs
+WHERE tenant_id = $1::text
+  AND lock = $2::text
+LIMIT 1
+`
+
+type GetJobByLockParams struct {
+	TenantID string `json:"tenant_id"`
+	Lock     string `json:"lock"`
+}
+
+func (q *Queries) GetJobByLock(ctx context.Context, arg GetJobByLockParams) (CronJob, error) {
+	row := q.db.QueryRow(ctx, getJobByLock, arg.TenantID, arg.Lock)
+	var i CronJob
+	err := row.Scan(
+		&i.ID,
+		&i.Lock,
+		&i.JobName,
+		&i.Status,
+		&i.LastExecutionTime,
+		&i.NextExecutionTime,
+		&i.LockedBy,
+		&i.LockedAt,
+		&i.TenantID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getStaleJobs = `-- name: GetStaleJobs :many
 SELECT id, "lock", job_name, tenant_id, locked_by, locked_at, status
 FROM cron_jobs
 WHERE status = 'running' 
-  AND locked_at < NOW() - INTERVAL '15 minutes'
-  AND tenant_id = $1::text
+  AND locked_at < $1::timestamptz
+  AND tenant_id = $2::text
 `
 
+type GetStaleJobsParams struct {
+	LockedBefore time.Time `json:"locked_before"`
+	TenantID     string    `json:"tenant_id"`
+}
+
 type GetStaleJobsRow struct {
 	ID       uuid.UUID          `json:"id"`
 	Lock     string             `json:"lock"`
@@ -225,8 +298,8 @@ type GetStaleJobsRow struct {
 }
 
 // NEW: Get jobs that are potentially stuck
-func (q *Queries) GetStaleJobs(ctx context.Context, tenantID string) ([]GetStaleJobsRow, error) {
-	rows, err := q.db.Query(ctx, getStaleJobs, tenantID)
+func (q *Queries) GetStaleJobs(ctx context.Context, arg GetStaleJobsParams) ([]GetStaleJobsRow, error) {
+	rows, err := q.db.Query(ctx, getStaleJobs, arg.LockedBefore, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -257,20 +330,21 @@ const isJobLocked = `-- name: IsJobLocked :one
 SELECT 
   id,
   CASE 
-    WHEN status = 'running' AND locked_at > NOW() - INTERVAL '10 minutes' THEN true
+    WHEN status = 'running' AND locked_at > $1::timestamptz THEN true
     ELSE false
   END as is_locked,
   locked_by,
   locked_at
 FROM cron_jobs
-WHERE tenant_id = $1::text 
-  AND lock = $2::text
+WHERE tenant_id = $2::text 
+  AND lock = $3::text
 LIMIT 1
 `
 
 type IsJobLockedParams struct {
-	TenantID string `json:"tenant_id"`
-	Lock     string `json:"lock"`
+	LockedAfter time.Time `json:"locked_after"`
+	TenantID    string    `json:"tenant_id"`
+	Lock        string    `json:"lock"`
 }
 
 type IsJobLockedRow struct {
@@ -282,7 +356,7 @@ type IsJobLockedRow struct {
 
 // NEW: Check if a job is currently locked by any instance
 func (q *Queries) IsJobLocked(ctx context.Context, arg IsJobLockedParams) (IsJobLockedRow, error) {
-	row := q.db.QueryRow(ctx, isJobLocked, arg.TenantID, arg.Lock)
+	row := q.db.QueryRow(ctx, isJobLocked, arg.LockedAfter, arg.TenantID, arg.Lock)
 	var i IsJobLockedRow
 	err := row.Scan(
 		&i.ID,
@@ -364,13 +438,16 @@ func (q *Queries) ListJobs(ctx context.Context, arg ListJobsParams) ([]CronJob,
 	return items, nil
 }
 
-const releaseAdvisoryLock = `-- name: ReleaseAdvisoryLock :exec
-SELECT pg_advisory_unlock($1::bigint)
+const releaseAdvisoryLock = `-- name: ReleaseAdvisoryLock :one
+SELECT pg_advisory_unlock($1::bigint) as lock_released
 `
 
-func (q *Queries) ReleaseAdvisoryLock(ctx context.Context, lockID int64) error {
-	_, err := q.db.Exec(ctx, releaseAdvisoryLock, lockID)
-	return err
+// Session-level lock: must run on the connection that acquired it
+func (q *Queries) ReleaseAdvisoryLock(ctx context.Context, lockID int64) (bool, error) {
+	row := q.db.QueryRow(ctx, releaseAdvisoryLock, lockID)
+	var lock_released bool
+	err := row.Scan(&lock_released)
+	return lock_released, err
 }
 
 const releaseTaskLock = `-- name: ReleaseTaskLock :exec
@@ -397,6 +474,7 @@ const tryAdvisoryLock = `-- name: TryAdvisoryLock :one
 SELECT pg_try_advisory_lock($1::bigint) as lock_acquired
 `
 
+// Session-level lock: held until released or until the connection is closed
 func (q *Queries) TryAdvisoryLock(ctx context.Context, lockID int64) (bool, error) {
 	row := q.db.QueryRow(ctx, tryAdvisoryLock, lockID)
 	var lock_acquired bool
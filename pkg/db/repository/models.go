@@ -5,12 +5,32 @@
 package repository
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CronBlackoutWindow struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	TenantID  string    `json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CronHoliday struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	HolidayDate time.Time `json:"holiday_date"`
+	TenantID    string    `json:"tenant_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 type CronJob struct {
 	ID                uuid.UUID          `json:"id"`
 	Lock              string             `json:"lock"`
@@ -40,17 +60,92 @@ type CronJobAuditLog struct {
 	TenantID      string           `json:"tenant_id"`
 	CreatedAt     pgtype.Timestamp `json:"created_at"`
 	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+	Result        json.RawMessage  `json:"result"`
+	Attempt       int32            `json:"attempt"`
+	ParentID      pgtype.UUID      `json:"parent_id"`
+	JitterMs      pgtype.Int4      `json:"jitter_ms"`
+	WorkflowRunID pgtype.UUID      `json:"workflow_run_id"`
+}
+
+type CronOneOffJob struct {
+	ID          uuid.UUID          `json:"id"`
+	JobType     string             `json:"job_type"`
+	RunAt       time.Time          `json:"run_at"`
+	Payload     json.RawMessage    `json:"payload"`
+	ClaimedBy   pgtype.Text        `json:"claimed_by"`
+	ClaimedAt   pgtype.Timestamptz `json:"claimed_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	TenantID    string             `json:"tenant_id"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 }
 
 type CronRegisteredJob struct {
-	ID               uuid.UUID `json:"id"`
-	JobName          string    `json:"job_name"`
-	Schedule         string    `json:"schedule"`
-	IsLongRunning    bool      `json:"is_long_running"`
-	IsEnabled        bool      `json:"is_enabled"`
-	LastRegisteredAt time.Time `json:"last_registered_at"`
-	InstanceID       string    `json:"instance_id"`
-	TenantID         string    `json:"tenant_id"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uuid.UUID       `json:"id"`
+	JobName          string          `json:"job_name"`
+	Schedule         string          `json:"schedule"`
+	IsLongRunning    bool            `json:"is_long_running"`
+	IsEnabled        bool            `json:"is_enabled"`
+	LastRegisteredAt time.Time       `json:"last_registered_at"`
+	InstanceID       string          `json:"instance_id"`
+	TenantID         string          `json:"tenant_id"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	ScheduleOverride pgtype.Text     `json:"schedule_override"`
+	TimeZone         pgtype.Text     `json:"time_zone"`
+	ScheduleType     string          `json:"schedule_type"`
+	Payload          json.RawMessage `json:"payload"`
+	JobType          pgtype.Text     `json:"job_type"`
+	Source           string          `json:"source"`
+}
+
+type CronRunRequest struct {
+	ID            uuid.UUID          `json:"id"`
+	JobName       string             `json:"job_name"`
+	UserID        string             `json:"user_id"`
+	ClaimedBy     pgtype.Text        `json:"claimed_by"`
+	ClaimedAt     pgtype.Timestamptz `json:"claimed_at"`
+	TenantID      string             `json:"tenant_id"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	WorkflowRunID pgtype.UUID        `json:"workflow_run_id"`
+	RunAfter      pgtype.Timestamptz `json:"run_after"`
+	ScheduledTime pgtype.Timestamptz `json:"scheduled_time"`
+}
+
+type CronWorkflow struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	FailurePolicy string    `json:"failure_policy"`
+	TenantID      string    `json:"tenant_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type CronWorkflowEdge struct {
+	WorkflowID    uuid.UUID `json:"workflow_id"`
+	UpstreamJob   string    `json:"upstream_job"`
+	DownstreamJob string    `json:"downstream_job"`
+	TenantID      string    `json:"tenant_id"`
+}
+
+type CronWorkflowRun struct {
+	ID         uuid.UUID          `json:"id"`
+	WorkflowID uuid.UUID          `json:"workflow_id"`
+	Status     string             `json:"status"`
+	StartedAt  time.Time          `json:"started_at"`
+	EndedAt    pgtype.Timestamptz `json:"ended_at"`
+	TenantID   string             `json:"tenant_id"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+type CronWorkflowRunNode struct {
+	WorkflowRunID uuid.UUID   `json:"workflow_run_id"`
+	JobName       string      `json:"job_name"`
+	Status        string      `json:"status"`
+	AuditLogID    pgtype.UUID `json:"audit_log_id"`
+	TenantID      string      `json:"tenant_id"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
 }
@@ -7,20 +7,29 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const cleanupStaleRegisteredJobs = `-- name: CleanupStaleRegisteredJobs :execresult
 DELETE FROM cron_registered_jobs
-WHERE last_registered_at < NOW() - INTERVAL '24 hours'
-  AND tenant_id = $1::text
+WHERE last_registered_at < $1::timestamptz
+  AND tenant_id = $2::text
+  AND source = 'code'
 `
 
-func (q *Queries) CleanupStaleRegisteredJobs(ctx context.Context, tenantID string) (pgconn.CommandTag, error) {
-	return q.db.Exec(ctx, cleanupStaleRegisteredJobs, tenantID)
+type CleanupStaleRegisteredJobsParams struct {
+	RegisteredBefore time.Time `json:"registered_before"`
+	TenantID         string    `json:"tenant_id"`
+}
+
+// Jobs defined through the API are not refreshed by instances, and are only removed through the API
+func (q *Queries) CleanupStaleRegisteredJobs(ctx context.Context, arg CleanupStaleRegisteredJobsParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, cleanupStaleRegisteredJobs, arg.RegisteredBefore, arg.TenantID)
 }
 
 const countJobAuditLogsByJobName = `-- name: CountJobAuditLogsByJobName :one
@@ -61,6 +70,84 @@ func (q *Queries) CountRegisteredJobs(ctx context.Context, arg CountRegisteredJo
 	return count, err
 }
 
+const createDefinedJob = `-- name: CreateDefinedJob :one
+INSERT INTO cron_registered_jobs (
+  job_name, job_type, schedule, schedule_type, payload, is_enabled,
+  last_registered_at, instance_id, tenant_id, source
+) VALUES (
+  $1::text,
+  $2::text,
+  $3::text,
+  $4::text,
+  $5::jsonb,
+  $6::boolean,
+  NOW(),
+  '',
+  $7::text,
+  'api'
+)
+RETURNING id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source
+`
+
+type CreateDefinedJobParams struct {
+	JobName      string          `json:"job_name"`
+	JobType      string          `json:"job_type"`
+	Schedule     string          `json:"schedule"`
+	ScheduleType string          `json:"schedule_type"`
+	Payload      json.RawMessage `json:"payload"`
+	IsEnabled    bool            `json:"is_enabled"`
+	TenantID     string          `json:"tenant_id"`
+}
+
+// Jobs defined through the API are not held by an instance
+func (q *Queries) CreateDefinedJob(ctx context.Context, arg CreateDefinedJobParams) (CronRegisteredJob, error) {
+	row := q.db.QueryRow(ctx, createDefinedJob,
+		arg.JobName,
+		arg.JobType,
+		arg.Schedule,
+		arg.ScheduleType,
+		arg.Payload,
+		arg.IsEnabled,
+		arg.TenantID,
+	)
+	var i CronRegisteredJob
+	err := row.Scan(
+		&i.ID,
+		&i.JobName,
+		&i.Schedule,
+		&i.IsLongRunning,
+		&i.IsEnabled,
+		&i.LastRegisteredAt,
+		&i.InstanceID,
+		&i.TenantID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ScheduleOverride,
+		&i.TimeZone,
+		&i.ScheduleType,
+		&i.Payload,
+		&i.JobType,
+		&i.Source,
+	)
+	return i, err
+}
+
+const deleteDefinedJob = `-- name: DeleteDefinedJob :execresult
+DELETE FROM cron_registered_jobs
+WHERE id = $1::uuid
+  AND tenant_id = $2::text
+  AND source = 'api'
+`
+
+type DeleteDefinedJobParams struct {
+	ID       uuid.UUID `json:"id"`
+	TenantID string    `json:"tenant_id"`
+}
+
+func (q *Queries) DeleteDefinedJob(ctx context.Context, arg DeleteDefinedJobParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, deleteDefinedJob, arg.ID, arg.TenantID)
+}
+
 const deleteRegisteredJob = `-- name: DeleteRegisteredJob :exec
 DELETE FROM cron_registered_jobs
 WHERE job_name = $1::text
@@ -78,7 +165,7 @@ func (q *Queries) DeleteRegisteredJob(ctx context.Context, arg DeleteRegisteredJ
 }
 
 const getRegisteredJobByID = `-- name: GetRegisteredJobByID :one
-SELECT id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at 
+SELECT id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source 
 FROM cron_registered_jobs
 WHERE id = $1::uuid
   AND tenant_id = $2::text
@@ -103,12 +190,99 @@ func (q *Queries) GetRegisteredJobByID(ctx context.Context, arg GetRegisteredJob
 		&i.TenantID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ScheduleOverride,
+		&i.TimeZone,
+		&i.ScheduleType,
+		&i.Payload,
+		&i.JobType,
+		&i.Source,
 	)
 	return i, err
 }
 
+const getRegisteredJobByName = `-- name: GetRegisteredJobByName :one
+SELECT id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source 
+FROM cron_registered_jobs
+WHERE job_name = $1::text
+  AND tenant_id = $2::text
+`
+
+type GetRegisteredJobByNameParams struct {
+	JobName  string `json:"job_name"`
+	TenantID string `json:"tenant_id"`
+}
+
+func (q *Queries) GetRegisteredJobByName(ctx context.Context, arg GetRegisteredJobByNameParams) (CronRegisteredJob, error) {
+	row := q.db.QueryRow(ctx, getRegisteredJobByName, arg.JobName, arg.TenantID)
+	var i CronRegisteredJob
+	err := row.Scan(
+		&i.ID,
+		&i.JobName,
+		&i.Schedule,
+		&i.IsLongRunning,
+		&i.IsEnabled,
+		&i.LastRegisteredAt,
+		&i.InstanceID,
+		&i.TenantID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ScheduleOverride,
+		&i.TimeZone,
+		&i.ScheduleType,
+		&i.Payload,
+		&i.JobType,
+		&i.Source,
+	)
+	return i, err
+}
+
+const listDefinedJobs = `-- name: ListDefinedJobs :many
+SELECT id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source 
+FROM cron_registered_jobs
+WHERE source = 'api'
+  AND job_type = ANY($1::text[])
+`
+
+// Jobs defined through the API with one of the given job types, of all tenants
+func (q *Queries) ListDefinedJobs(ctx context.Context, jobTypes []string) ([]CronRegisteredJob, error) {
+	rows, err := q.db.Query(ctx, listDefinedJobs, jobTypes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CronRegisteredJob{}
+	for rows.Next() {
+		var i CronRegisteredJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.JobName,
+			&i.Schedule,
+			&i.IsLongRunning,
+			&i.IsEnabled,
+			&i.LastRegisteredAt,
+			&i.InstanceID,
+			&i.TenantID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ScheduleOverride,
+			&i.TimeZone,
+			&i.ScheduleType,
+			&i.Payload,
+			&i.JobType,
+			&i.Source,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listJobAuditLogsByJobName = `-- name: ListJobAuditLogsByJobName :many
-SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at 
+SELECT id, app_id, request_id, job_name, scheduled_time, start_time, end_time, status, output, error, user_id, tenant_id, created_at, updated_at, result, attempt, parent_id, jitter_ms, workflow_run_id 
 FROM cron_job_audit_logs
 WHERE job_name = $1::text
   AND tenant_id = $2::text
@@ -165,6 +339,11 @@ func (q *Queries) ListJobAuditLogsByJobName(ctx context.Context, arg ListJobAudi
 			&i.TenantID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Result,
+			&i.Attempt,
+			&i.ParentID,
+			&i.JitterMs,
+			&i.WorkflowRunID,
 		); err != nil {
 			return nil, err
 		}
@@ -177,7 +356,7 @@ func (q *Queries) ListJobAuditLogsByJobName(ctx context.Context, arg ListJobAudi
 }
 
 const listRegisteredJobs = `-- name: ListRegisteredJobs :many
-SELECT rj.id, rj.job_name, rj.schedule, rj.is_long_running, rj.is_enabled, rj.last_registered_at, rj.instance_id, rj.tenant_id, rj.created_at, rj.updated_at, 
+SELECT rj.id, rj.job_name, rj.schedule, rj.is_long_running, rj.is_enabled, rj.last_registered_at, rj.instance_id, rj.tenant_id, rj.created_at, rj.updated_at, rj.schedule_override, rj.time_zone, rj.schedule_type, rj.payload, rj.job_type, rj.source, 
   (SELECT COUNT(*) FROM cron_job_audit_logs al 
    WHERE al.job_name = rj.job_name AND al.tenant_id = rj.tenant_id) as execution_count
 FROM cron_registered_jobs rj
@@ -206,17 +385,8 @@ type ListRegisteredJobsParams struct {
 }
 
 type ListRegisteredJobsRow struct {
-	ID               uuid.UUID `json:"id"`
-	JobName          string    `json:"job_name"`
-	Schedule         string    `json:"schedule"`
-	IsLongRunning    bool      `json:"is_long_running"`
-	IsEnabled        bool      `json:"is_enabled"`
-	LastRegisteredAt time.Time `json:"last_registered_at"`
-	InstanceID       string    `json:"instance_id"`
-	TenantID         string    `json:"tenant_id"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
-	ExecutionCount   int64     `json:"execution_count"`
+	CronRegisteredJob CronRegisteredJob `json:"cron_registered_job"`
+	ExecutionCount    int64             `json:"execution_count"`
 }
 
 func (q *Queries) ListRegisteredJobs(ctx context.Context, arg ListRegisteredJobsParams) ([]ListRegisteredJobsRow, error) {
@@ -235,6 +405,50 @@ func (q *Queries) ListRegisteredJobs(ctx context.Context, arg ListRegisteredJobs
 	items := []ListRegisteredJobsRow{}
 	for rows.Next() {
 		var i ListRegisteredJobsRow
+		if err := rows.Scan(
+			&i.CronRegisteredJob.ID,
+			&i.CronRegisteredJob.JobName,
+			&i.CronRegisteredJob.Schedule,
+			&i.CronRegisteredJob.IsLongRunning,
+			&i.CronRegisteredJob.IsEnabled,
+			&i.CronRegisteredJob.LastRegisteredAt,
+			&i.CronRegisteredJob.InstanceID,
+			&i.CronRegisteredJob.TenantID,
+			&i.CronRegisteredJob.CreatedAt,
+			&i.CronRegisteredJob.UpdatedAt,
+			&i.CronRegisteredJob.ScheduleOverride,
+			&i.CronRegisteredJob.TimeZone,
+			&i.CronRegisteredJob.ScheduleType,
+			&i.CronRegisteredJob.Payload,
+			&i.CronRegisteredJob.JobType,
+			&i.CronRegisteredJob.Source,
+			&i.ExecutionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRegisteredJobsByTenants = `-- name: ListRegisteredJobsByTenants :many
+SELECT id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source 
+FROM cron_registered_jobs
+WHERE tenant_id = ANY($1::text[])
+`
+
+func (q *Queries) ListRegisteredJobsByTenants(ctx context.Context, tenantIds []string) ([]CronRegisteredJob, error) {
+	rows, err := q.db.Query(ctx, listRegisteredJobsByTenants, tenantIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CronRegisteredJob{}
+	for rows.Next() {
+		var i CronRegisteredJob
 		if err := rows.Scan(
 			&i.ID,
 			&i.JobName,
@@ -246,7 +460,12 @@ func (q *Queries) ListRegisteredJobs(ctx context.Context, arg ListRegisteredJobs
 			&i.TenantID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
-			&i.ExecutionCount,
+			&i.ScheduleOverride,
+			&i.TimeZone,
+			&i.ScheduleType,
+			&i.Payload,
+			&i.JobType,
+			&i.Source,
 		); err != nil {
 			return nil, err
 		}
@@ -258,6 +477,24 @@ func (q *Queries) ListRegisteredJobs(ctx context.Context, arg ListRegisteredJobs
 	return items, nil
 }
 
+const touchRegisteredJobs = `-- name: TouchRegisteredJobs :execresult
+UPDATE cron_registered_jobs rj
+SET last_registered_at = NOW()
+FROM UNNEST($1::text[], $2::text[]) AS j(tenant_id, job_name)
+WHERE rj.tenant_id = j.tenant_id
+  AND rj.job_name = j.job_name
+`
+
+type TouchRegisteredJobsParams struct {
+	TenantIds []string `json:"tenant_ids"`
+	JobNames  []string `json:"job_names"`
+}
+
+// Keep registrations of jobs held by a running instance from being cleaned up
+func (q *Queries) TouchRegisteredJobs(ctx context.Context, arg TouchRegisteredJobsParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, touchRegisteredJobs, arg.TenantIds, arg.JobNames)
+}
+
 const updateRegisteredJobEnabled = `-- name: UpdateRegisteredJobEnabled :execresult
 UPDATE cron_registered_jobs
 SET is_enabled = $1::boolean,
@@ -276,10 +513,48 @@ func (q *Queries) UpdateRegisteredJobEnabled(ctx context.Context, arg UpdateRegi
 	return q.db.Exec(ctx, updateRegisteredJobEnabled, arg.IsEnabled, arg.ID, arg.TenantID)
 }
 
+const updateRegisteredJobPayload = `-- name: UpdateRegisteredJobPayload :execresult
+UPDATE cron_registered_jobs
+SET payload = $1::jsonb,
+    updated_at = NOW()
+WHERE id = $2::uuid
+  AND tenant_id = $3::text
+`
+
+type UpdateRegisteredJobPayloadParams struct {
+	Payload  json.RawMessage `json:"payload"`
+	ID       uuid.UUID       `json:"id"`
+	TenantID string          `json:"tenant_id"`
+}
+
+// A NULL payload removes the payload of the job
+func (q *Queries) UpdateRegisteredJobPayload(ctx context.Context, arg UpdateRegisteredJobPayloadParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updateRegisteredJobPayload, arg.Payload, arg.ID, arg.TenantID)
+}
+
+const updateRegisteredJobScheduleOverride = `-- name: UpdateRegisteredJobScheduleOverride :execresult
+UPDATE cron_registered_jobs
+SET schedule_override = $1::text,
+    updated_at = NOW()
+WHERE id = $2::uuid
+  AND tenant_id = $3::text
+`
+
+type UpdateRegisteredJobScheduleOverrideParams struct {
+	ScheduleOverride pgtype.Text `json:"schedule_override"`
+	ID               uuid.UUID   `json:"id"`
+	TenantID         string      `json:"tenant_id"`
+}
+
+// A NULL schedule_override reverts the job to the schedule defined in code
+func (q *Queries) UpdateRegisteredJobScheduleOverride(ctx context.Context, arg UpdateRegisteredJobScheduleOverrideParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updateRegisteredJobScheduleOverride, arg.ScheduleOverride, arg.ID, arg.TenantID)
+}
+
 const upsertRegisteredJob = `-- name: UpsertRegisteredJob :one
 INSERT INTO cron_registered_jobs (
   job_name, schedule, is_long_running, is_enabled, 
-  last_registered_at, instance_id, tenant_id
+  last_registered_at, instance_id, tenant_id, time_zone, schedule_type
 ) VALUES (
   $1::text,
   $2::text,
@@ -287,28 +562,35 @@ INSERT INTO cron_registered_jobs (
   $4::boolean,
   NOW(),
   $5::text,
-  $6::text
+  $6::text,
+  $7::text,
+  $8::text
 )
 ON CONFLICT (tenant_id, job_name) DO UPDATE
 SET 
   schedule = EXCLUDED.schedule,
   is_long_running = EXCLUDED.is_long_running,
-  is_enabled = EXCLUDED.is_enabled,
+  time_zone = EXCLUDED.time_zone,
+  schedule_type = EXCLUDED.schedule_type,
   last_registered_at = NOW(),
   instance_id = EXCLUDED.instance_id,
   updated_at = NOW()
-RETURNING id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at
+WHERE cron_registered_jobs.source = 'code'
+RETURNING id, job_name, schedule, is_long_running, is_enabled, last_registered_at, instance_id, tenant_id, created_at, updated_at, schedule_override, time_zone, schedule_type, payload, job_type, source
 `
 
 type UpsertRegisteredJobParams struct {
-	JobName       string `json:"job_name"`
-	Schedule      string `json:"schedule"`
-	IsLongRunning bool   `json:"is_long_running"`
-	IsEnabled     bool   `json:"is_enabled"`
-	InstanceID    string `json:"instance_id"`
-	TenantID      string `json:"tenant_id"`
+	JobName       string      `json:"job_name"`
+	Schedule      string      `json:"schedule"`
+	IsLongRunning bool        `json:"is_long_running"`
+	IsEnabled     bool        `json:"is_enabled"`
+	InstanceID    string      `json:"instance_id"`
+	TenantID      string      `json:"tenant_id"`
+	TimeZone      pgtype.Text `json:"time_zone"`
+	ScheduleType  string      `json:"schedule_type"`
 }
 
+// is_enabled is only set on insert so that re-registration keeps the operator's choice
 func (q *Queries) UpsertRegisteredJob(ctx context.Context, arg UpsertRegisteredJobParams) (CronRegisteredJob, error) {
 	row := q.db.QueryRow(ctx, upsertRegisteredJob,
 		arg.JobName,
@@ -317,6 +599,8 @@ func (q *Queries) UpsertRegisteredJob(ctx context.Context, arg UpsertRegisteredJ
 		arg.IsEnabled,
 		arg.InstanceID,
 		arg.TenantID,
+		arg.TimeZone,
+		arg.ScheduleType,
 	)
 	var i CronRegisteredJob
 	err := row.Scan(
@@ -330,6 +614,12 @@ func (q *Queries) UpsertRegisteredJob(ctx context.Context, arg UpsertRegisteredJ
 		&i.TenantID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ScheduleOverride,
+		&i.TimeZone,
+		&i.ScheduleType,
+		&i.Payload,
+		&i.JobType,
+		&i.Source,
 	)
 	return i, err
 }
@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// SuppressionPolicy defines what happens to a scheduled run of a job falling in a blackout window
+// or on a holiday of its tenant
+type SuppressionPolicy int
+
+const (
+	// SuppressionSkip skips the suppressed run, the default
+	SuppressionSkip SuppressionPolicy = iota
+
+	// SuppressionDefer runs the job once at the end of the blackout window or holiday
+	SuppressionDefer
+)
+
+// SuppressibleJob is implemented by jobs choosing what happens to their suppressed runs
+type SuppressibleJob interface {
+	Job
+
+	// SuppressionPolicy returns what happens to the suppressed runs of the job
+	SuppressionPolicy() SuppressionPolicy
+}
+
+// suppressionPolicyOf returns the suppression policy of the job, SuppressionSkip by default
+func suppressionPolicyOf(job Job) SuppressionPolicy {
+	if suppressibleJob, ok := job.(SuppressibleJob); ok {
+		return suppressibleJob.SuppressionPolicy()
+	}
+	return SuppressionSkip
+}
+
+// maxSuppressionSteps bounds the consecutive blackout windows and holidays a suppression spans
+const maxSuppressionSteps = 366
+
+// calendarReader reads the blackout windows and holidays of tenants, implemented by the store
+type calendarReader interface {
+	GetActiveBlackoutWindow(ctx context.Context, arg repository.GetActiveBlackoutWindowParams) (repository.CronBlackoutWindow, error)
+	GetHolidayByDate(ctx context.Context, arg repository.GetHolidayByDateParams) (repository.CronHoliday, error)
+}
+
+// suppressionEnd returns when a run of the job at t stops being suppressed by the blackout windows
+// and holidays of its tenant, with the reason of the suppression. It returns the zero time when a
+// run at t is not suppressed. Holidays cover the whole day in the time zone of the job.
+func suppressionEnd(ctx context.Context, calendars calendarReader, job Job, t time.Time) (time.Time, string, error) {
+	tenantID := job.TenantID()
+	loc := locationOf(job)
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var end time.Time
+	reason := ""
+	// Adjacent windows and holidays are a single suppression
+	for i := 0; i < maxSuppressionSteps; i++ {
+		window, err := calendars.GetActiveBlackoutWindow(ctx, repository.GetActiveBlackoutWindowParams{
+			TenantID: tenantID,
+			At:       t,
+		})
+		if err == nil {
+			if reason == "" {
+				reason = fmt.Sprintf("Blackout window %s", window.Name)
+			}
+			t = window.EndTime
+			end = t
+			continue
+		}
+		if err.Error() != pgx.ErrNoRows.Error() {
+			return time.Time{}, "", err
+		}
+
+		local := t.In(loc)
+		holiday, err := calendars.GetHolidayByDate(ctx, repository.GetHolidayByDateParams{
+			TenantID:    tenantID,
+			HolidayDate: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC),
+		})
+		if err != nil {
+			if err.Error() == pgx.ErrNoRows.Error() {
+				break
+			}
+			return time.Time{}, "", err
+		}
+		if reason == "" {
+			reason = fmt.Sprintf("Holiday %s", holiday.Name)
+		}
+		t = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+		end = t
+	}
+	return end, reason, nil
+}
+
+// isRunSuppressed checks whether a run of the job starting at now is suppressed. A suppressed run
+// is recorded as "suppressed" in its audit log and deferred if the job asks for it or is a one-off job,
+// deferred reporting whether a deferred run is pending. It is called under the advisory lock of the job,
+// so the instances firing the same run defer it once.
+func (jm *JobManager) isRunSuppressed(job Job, execution jobExecution, auditLogID uuid.UUID, now time.Time) (suppressed bool, deferred bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	until, reason, err := suppressionEnd(ctx, jm.store, job, now)
+	if err != nil {
+		// Run the job rather than miss it because the calendars cannot be read
+		log.Printf("Error checking blackout windows and holidays of job %s (tenant %s): %v", job.Name(), job.TenantID(), err)
+		return false, false
+	}
+	if until.IsZero() {
+		return false, false
+	}
+
+	log.Printf("Run of job %s for tenant %s suppressed until %s: %s", job.Name(), job.TenantID(), until, reason)
+	errorMsg := fmt.Sprintf("%s until %s", reason, until.Format(time.RFC3339))
+	// One-off jobs have a single run, which is always deferred
+	if suppressionPolicyOf(job) == SuppressionDefer || execution.oneOffJobID != uuid.Nil {
+		created, err := jm.deferRun(ctx, job, execution, until, now)
+		switch {
+		case err != nil:
+			log.Printf("Error deferring run of job %s (tenant %s): %v", job.Name(), job.TenantID(), err)
+			errorMsg += ", run could not be deferred"
+		case created:
+			errorMsg += ", run deferred"
+		default:
+			errorMsg += ", run already deferred"
+		}
+		deferred = err == nil
+	}
+	jm.updateAuditLogStatus(auditLogID, "suppressed", nil, &errorMsg, job.TenantID())
+	return true, deferred
+}
+
+// deferRun stores a run of the job at until, so it survives restarts and runs on any instance holding
+// the job: a deferred run request, part of the workflow run of the execution if any, or the one-off job
+// moved to until. It returns false when a deferred run of the job is already pending, so the runs
+// suppressed by one window are deferred only once.
+func (jm *JobManager) deferRun(ctx context.Context, job Job, execution jobExecution, until time.Time, now time.Time) (bool, error) {
+	// One-off jobs are not held by any instance, they are claimed again once due
+	if execution.oneOffJobID != uuid.Nil {
+		err := jm.store.DeferOneOffJob(ctx, repository.DeferOneOffJobParams{
+			RunAt:      until,
+			ID:         execution.oneOffJobID,
+			InstanceID: jm.instanceID,
+		})
+		return err == nil, err
+	}
+
+	// The deferred run keeps the fire time it belongs to, so its delay shows the deferral
+	scheduledTime := execution.scheduledTime
+	if scheduledTime.IsZero() {
+		scheduledTime = now
+	}
+	result, err := jm.store.CreateDeferredRunRequest(ctx, repository.CreateDeferredRunRequestParams{
+		JobName:       job.Name(),
+		UserID:        execution.userID,
+		TenantID:      job.TenantID(),
+		RunAfter:      until,
+		ScheduledTime: scheduledTime,
+		WorkflowRunID: pgtype.UUID{Bytes: execution.workflowRunID, Valid: execution.workflowRunID != uuid.Nil},
+		ExpiredBefore: now.Add(-jm.options.runRequestExpiry),
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
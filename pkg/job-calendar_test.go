@@ -0,0 +1,203 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// testCalendar holds the blackout windows and holidays of a tenant in memory
+type testCalendar struct {
+	windows  []repository.CronBlackoutWindow
+	holidays []repository.CronHoliday
+	err      error
+}
+
+// GetActiveBlackoutWindow returns the active window ending last, like the query
+func (c *testCalendar) GetActiveBlackoutWindow(ctx context.Context, arg repository.GetActiveBlackoutWindowParams) (repository.CronBlackoutWindow, error) {
+	if c.err != nil {
+		return repository.CronBlackoutWindow{}, c.err
+	}
+	var active *repository.CronBlackoutWindow
+	for i, window := range c.windows {
+		if window.StartTime.After(arg.At) || !window.EndTime.After(arg.At) {
+			continue
+		}
+		if active == nil || window.EndTime.After(active.EndTime) {
+			active = &c.windows[i]
+		}
+	}
+	if active == nil {
+		return repository.CronBlackoutWindow{}, pgx.ErrNoRows
+	}
+	return *active, nil
+}
+
+func (c *testCalendar) GetHolidayByDate(ctx context.Context, arg repository.GetHolidayByDateParams) (repository.CronHoliday, error) {
+	for _, holiday := range c.holidays {
+		if holiday.HolidayDate.Equal(arg.HolidayDate) {
+			return holiday, nil
+		}
+	}
+	return repository.CronHoliday{}, pgx.ErrNoRows
+}
+
+func TestSuppressionEnd(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, 12, day, hour, min, 0, 0, time.UTC)
+	}
+	window := func(name string, start, end time.Time) repository.CronBlackoutWindow {
+		return repository.CronBlackoutWindow{Name: name, StartTime: start, EndTime: end}
+	}
+	holiday := func(name string, day int) repository.CronHoliday {
+		return repository.CronHoliday{Name: name, HolidayDate: time.Date(2025, 12, day, 0, 0, 0, 0, time.UTC)}
+	}
+	errCalendar := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		calendar     testCalendar
+		location     *time.Location
+		at           time.Time
+		expectEnd    time.Time
+		expectReason string
+		expectError  bool
+	}{
+		{
+			name: "Outside any window or holiday",
+			calendar: testCalendar{
+				windows:  []repository.CronBlackoutWindow{window("Deploy", at(10, 2, 0), at(10, 4, 0))},
+				holidays: []repository.CronHoliday{holiday("Christmas", 25)},
+			},
+			at: at(10, 5, 0),
+		},
+		{
+			name:         "Inside a window",
+			calendar:     testCalendar{windows: []repository.CronBlackoutWindow{window("Deploy", at(10, 2, 0), at(10, 4, 0))}},
+			at:           at(10, 3, 0),
+			expectEnd:    at(10, 4, 0),
+			expectReason: "Blackout window Deploy",
+		},
+		{
+			name:     "At the end of a window",
+			calendar: testCalendar{windows: []repository.CronBlackoutWindow{window("Deploy", at(10, 2, 0), at(10, 4, 0))}},
+			at:       at(10, 4, 0),
+		},
+		{
+			name: "Overlapping windows are chained",
+			calendar: testCalendar{windows: []repository.CronBlackoutWindow{
+				window("Deploy", at(10, 2, 0), at(10, 4, 0)),
+				window("Migration", at(10, 3, 0), at(10, 6, 0)),
+			}},
+			at:           at(10, 2, 30),
+			expectEnd:    at(10, 6, 0),
+			expectReason: "Blackout window Deploy",
+		},
+		{
+			name: "Window containing another one",
+			calendar: testCalendar{windows: []repository.CronBlackoutWindow{
+				window("Freeze", at(10, 0, 0), at(10, 8, 0)),
+				window("Deploy", at(10, 2, 0), at(10, 4, 0)),
+			}},
+			at:           at(10, 3, 0),
+			expectEnd:    at(10, 8, 0),
+			expectReason: "Blackout window Freeze",
+		},
+		{
+			name: "Adjacent windows are chained",
+			calendar: testCalendar{windows: []repository.CronBlackoutWindow{
+				window("Deploy", at(10, 2, 0), at(10, 4, 0)),
+				window("Checks", at(10, 4, 0), at(10, 5, 0)),
+			}},
+			at:           at(10, 3, 0),
+			expectEnd:    at(10, 5, 0),
+			expectReason: "Blackout window Deploy",
+		},
+		{
+			name:         "Holiday covers the whole day",
+			calendar:     testCalendar{holidays: []repository.CronHoliday{holiday("Christmas", 25)}},
+			location:     time.UTC,
+			at:           at(25, 15, 0),
+			expectEnd:    at(26, 0, 0),
+			expectReason: "Holiday Christmas",
+		},
+		{
+			name:         "Consecutive holidays are chained",
+			calendar:     testCalendar{holidays: []repository.CronHoliday{holiday("Christmas Eve", 24), holiday("Christmas", 25)}},
+			location:     time.UTC,
+			at:           at(24, 10, 0),
+			expectEnd:    at(26, 0, 0),
+			expectReason: "Holiday Christmas Eve",
+		},
+		{
+			name: "Window ending on a holiday",
+			calendar: testCalendar{
+				windows:  []repository.CronBlackoutWindow{window("Freeze", at(24, 20, 0), at(25, 2, 0))},
+				holidays: []repository.CronHoliday{holiday("Christmas", 25)},
+			},
+			location:     time.UTC,
+			at:           at(24, 22, 0),
+			expectEnd:    at(26, 0, 0),
+			expectReason: "Blackout window Freeze",
+		},
+		{
+			name: "Holiday followed by a window",
+			calendar: testCalendar{
+				windows:  []repository.CronBlackoutWindow{window("Inventory", at(26, 0, 0), at(26, 6, 0))},
+				holidays: []repository.CronHoliday{holiday("Christmas", 25)},
+			},
+			location:     time.UTC,
+			at:           at(25, 12, 0),
+			expectEnd:    at(26, 6, 0),
+			expectReason: "Holiday Christmas",
+		},
+		{
+			name:     "Holiday in the time zone of the job",
+			calendar: testCalendar{holidays: []repository.CronHoliday{holiday("Christmas", 25)}},
+			location: tokyo,
+			// December 25 at 08:30 in Tokyo
+			at:           at(24, 23, 30),
+			expectEnd:    time.Date(2025, 12, 26, 0, 0, 0, 0, tokyo),
+			expectReason: "Holiday Christmas",
+		},
+		{
+			name:     "Day before a holiday in the time zone of the job",
+			calendar: testCalendar{holidays: []repository.CronHoliday{holiday("Christmas", 25)}},
+			location: tokyo,
+			// December 24 at 23:30 in Tokyo
+			at: at(24, 14, 30),
+		},
+		{
+			name:        "Calendars cannot be read",
+			calendar:    testCalendar{err: errCalendar},
+			at:          at(10, 3, 0),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &locatedTestJob{schedule: "@hourly", location: tt.location}
+			if tt.location == nil {
+				job.location = time.UTC
+			}
+
+			end, reason, err := suppressionEnd(context.Background(), &tt.calendar, job, tt.at)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("suppressionEnd() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !end.Equal(tt.expectEnd) {
+				t.Errorf("suppressionEnd() end = %s, want %s", end, tt.expectEnd)
+			}
+			if reason != tt.expectReason {
+				t.Errorf("suppressionEnd() reason = %q, want %q", reason, tt.expectReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// Sources of registered jobs, stored as source of cron_registered_jobs
+const (
+	JobSourceCode = "code" // Jobs registered in code with RegisterJob
+	JobSourceAPI  = "api"  // Jobs defined through the API, built by the factory of their job type
+)
+
+// syncDefinedJobs builds the jobs defined through the API whose job type is registered on this
+// instance, and schedules them. Jobs whose definition changed are rebuilt, and jobs whose row was
+// deleted are removed. Every instance holds these jobs, the locks run each of their runs once.
+func (jm *JobManager) syncDefinedJobs() {
+	jm.mutex.Lock()
+	jobTypes := jm.jobTypeNames()
+	jm.mutex.Unlock()
+
+	if len(jobTypes) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	definedJobs, err := jm.store.ListDefinedJobs(ctx, jobTypes)
+	if err != nil {
+		log.Printf("Error loading jobs defined through the API: %v", err)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, definedJob := range definedJobs {
+		key := jobKey(definedJob.JobName, definedJob.TenantID)
+		seen[key] = true
+
+		// updated_at also changes when instances refresh the row, e.g. with TouchRegisteredJobs
+		definition := definitionOf(definedJob)
+		jm.mutex.Lock()
+		builtFrom, exists := jm.defined[key]
+		jm.mutex.Unlock()
+		if exists && builtFrom == definition {
+			continue
+		}
+
+		job, err := jm.buildDefinedJob(definedJob)
+		if err != nil {
+			log.Printf("Error building job %s of type %s (tenant %s): %v", definedJob.JobName, definedJob.JobType.String, definedJob.TenantID, err)
+			continue
+		}
+		jm.addDefinedJob(key, job, definition)
+	}
+
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	for key := range jm.defined {
+		if !seen[key] {
+			jm.removeDefinedJob(key)
+		}
+	}
+}
+
+// definitionOf returns what a job defined through the API is built from. Its schedule override
+// and is_enabled flag are applied without rebuilding the job.
+func definitionOf(definedJob repository.CronRegisteredJob) string {
+	return strings.Join([]string{definedJob.JobType.String, definedJob.Schedule, string(definedJob.Payload)}, "\x00")
+}
+
+// buildDefinedJob builds a job defined through the API with the factory of its job type
+func (jm *JobManager) buildDefinedJob(definedJob repository.CronRegisteredJob) (Job, error) {
+	return jm.buildJob(definedJob.JobType.String, JobSpec{
+		Name:     definedJob.JobName,
+		TenantID: definedJob.TenantID,
+		Schedule: definedJob.Schedule,
+		Payload:  definedJob.Payload,
+	})
+}
+
+// addDefinedJob adds or replaces a job defined through the API, built from the given definition.
+// Unlike RegisterJob, its row is left as is.
+func (jm *JobManager) addDefinedJob(key string, job Job, definition string) {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	replaced := false
+	for i, existingJob := range jm.jobs {
+		if jobKey(existingJob.Name(), existingJob.TenantID()) == key {
+			jm.jobs[i] = job
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		jm.jobs = append(jm.jobs, job)
+	}
+	jm.defined[key] = definition
+
+	if jm.isRunning {
+		jm.scheduleJob(job)
+	}
+	log.Printf("Job %s for tenant %s defined through the API loaded", job.Name(), job.TenantID())
+}
+
+// removeDefinedJob removes a job defined through the API whose row was deleted.
+// The caller must hold jm.mutex.
+func (jm *JobManager) removeDefinedJob(key string) {
+	if entryID, exists := jm.entryIDs[key]; exists {
+		jm.cron.Remove(entryID)
+		delete(jm.entryIDs, key)
+		delete(jm.schedules, key)
+	}
+	for i, job := range jm.jobs {
+		if jobKey(job.Name(), job.TenantID()) == key {
+			jm.jobs = append(jm.jobs[:i], jm.jobs[i+1:]...)
+			log.Printf("Job %s for tenant %s defined through the API removed", job.Name(), job.TenantID())
+			break
+		}
+	}
+	delete(jm.defined, key)
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+func TestDefinitionOf(t *testing.T) {
+	base := repository.CronRegisteredJob{
+		JobName:          "webhook",
+		JobType:          pgtype.Text{String: HTTPJobType, Valid: true},
+		Schedule:         "@hourly",
+		Payload:          json.RawMessage(`{"url": "https://example.com/hook"}`),
+		TenantID:         "tenant",
+		LastRegisteredAt: time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:        time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name          string
+		change        func(definedJob *repository.CronRegisteredJob)
+		expectRebuild bool
+	}{
+		{
+			name: "Row refreshed by an instance",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.LastRegisteredAt = definedJob.LastRegisteredAt.Add(5 * time.Minute)
+				definedJob.UpdatedAt = definedJob.UpdatedAt.Add(5 * time.Minute)
+			},
+		},
+		{
+			name: "Job disabled",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.IsEnabled = false
+			},
+		},
+		{
+			name: "Schedule overridden",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.ScheduleOverride = pgtype.Text{String: "@daily", Valid: true}
+			},
+		},
+		{
+			name: "Schedule changed",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.Schedule = "@daily"
+			},
+			expectRebuild: true,
+		},
+		{
+			name: "Payload changed",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.Payload = json.RawMessage(`{"url": "https://example.com/other"}`)
+			},
+			expectRebuild: true,
+		},
+		{
+			name: "Job type changed",
+			change: func(definedJob *repository.CronRegisteredJob) {
+				definedJob.JobType = pgtype.Text{String: SQLJobType, Valid: true}
+			},
+			expectRebuild: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := base
+			tt.change(&changed)
+			if rebuild := definitionOf(changed) != definitionOf(base); rebuild != tt.expectRebuild {
+				t.Errorf("definition changed = %v, want %v", rebuild, tt.expectRebuild)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// JobSpec describes a job built by a JobFactory
+type JobSpec struct {
+	// Name is the name of the job, unique for its tenant. Jobs built from a spec should also use it
+	// as their lock, e.g. so that one-off jobs of the same type do not lock each other out.
+	Name string
+
+	// TenantID is the tenant the job runs for
+	TenantID string
+
+	// Schedule is the schedule of the job, which jobs built from a spec return from Schedule.
+	// It is empty for one-off jobs.
+	Schedule string
+
+	// Payload is the configuration of the job, nil when not set
+	Payload json.RawMessage
+}
+
+// JobFactory builds the jobs of a job type from their spec
+type JobFactory func(spec JobSpec) (Job, error)
+
+// errJobTypeNotRegistered is the error of a job whose job type has no factory on this instance
+var errJobTypeNotRegistered = errors.New("job type not registered")
+
+// RegisterJobType registers the factory building the jobs of a job type.
+// An instance only runs the one-off jobs and the jobs defined through the API of the types it registered.
+func (jm *JobManager) RegisterJobType(jobType string, factory JobFactory) {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	if _, exists := jm.jobTypes[jobType]; exists {
+		log.Printf("Job type %s already registered, replacing its factory", jobType)
+	}
+	jm.jobTypes[jobType] = factory
+}
+
+// jobFactory returns the factory of a job type, or nil when the type is not registered
+func (jm *JobManager) jobFactory(jobType string) JobFactory {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	return jm.jobTypes[jobType]
+}
+
+// jobTypeNames returns the registered job types, sorted. The caller must hold jm.mutex.
+func (jm *JobManager) jobTypeNames() []string {
+	jobTypes := make([]string, 0, len(jm.jobTypes))
+	for jobType := range jm.jobTypes {
+		jobTypes = append(jobTypes, jobType)
+	}
+	sort.Strings(jobTypes)
+	return jobTypes
+}
+
+// IsJobTypeRegistered returns whether a factory is registered for the job type
+func (jm *JobManager) IsJobTypeRegistered(jobType string) bool {
+	return jm.jobFactory(jobType) != nil
+}
+
+// ValidateJob builds a job of the job type from its spec and discards it, so that a job defined
+// through the API whose factory rejects its spec, e.g. because of a malformed payload, is refused
+// when it is defined instead of failing on every sync.
+func (jm *JobManager) ValidateJob(jobType string, spec JobSpec) error {
+	_, err := jm.buildJob(jobType, spec)
+	return err
+}
+
+// buildJob builds a job of the job type from its spec with the factory of the type
+func (jm *JobManager) buildJob(jobType string, spec JobSpec) (Job, error) {
+	factory := jm.jobFactory(jobType)
+	if factory == nil {
+		return nil, errJobTypeNotRegistered
+	}
+	job, err := factory(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The job is identified by the name and tenant of its spec
+	if job.Name() != spec.Name || job.TenantID() != spec.TenantID {
+		return nil, fmt.Errorf("factory built job %s for tenant %s instead", job.Name(), job.TenantID())
+	}
+	return job, nil
+}
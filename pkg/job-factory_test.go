@@ -0,0 +1,90 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// factoryTestJob is a job built by a test factory
+type factoryTestJob struct {
+	Job
+	name     string
+	tenantID string
+}
+
+func (j *factoryTestJob) Name() string     { return j.name }
+func (j *factoryTestJob) TenantID() string { return j.tenantID }
+
+func TestValidateJob(t *testing.T) {
+	// Builds jobs whose payload names the target, renaming them when asked to
+	factory := func(spec JobSpec) (Job, error) {
+		var config struct {
+			Target string `json:"target"`
+			Rename string `json:"rename"`
+		}
+		if err := json.Unmarshal(spec.Payload, &config); err != nil {
+			return nil, err
+		}
+		if config.Target == "" {
+			return nil, errors.New("target is required")
+		}
+		name := spec.Name
+		if config.Rename != "" {
+			name = config.Rename
+		}
+		return &factoryTestJob{name: name, tenantID: spec.TenantID}, nil
+	}
+	jm := &JobManager{jobTypes: map[string]JobFactory{"sync": factory}}
+
+	tests := []struct {
+		name        string
+		jobType     string
+		payload     string
+		expectError bool
+	}{
+		{
+			name:    "Valid spec",
+			jobType: "sync",
+			payload: `{"target": "crm"}`,
+		},
+		{
+			name:        "Job type not registered",
+			jobType:     "export",
+			payload:     `{"target": "crm"}`,
+			expectError: true,
+		},
+		{
+			name:        "Malformed payload",
+			jobType:     "sync",
+			payload:     `{"target": `,
+			expectError: true,
+		},
+		{
+			name:        "Payload rejected by the factory",
+			jobType:     "sync",
+			payload:     `{}`,
+			expectError: true,
+		},
+		{
+			name:        "Job built with another name",
+			jobType:     "sync",
+			payload:     `{"target": "crm", "rename": "other"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := jm.ValidateJob(tt.jobType, JobSpec{
+				Name:     "crm-sync",
+				TenantID: "tenant",
+				Schedule: "@hourly",
+				Payload:  json.RawMessage(tt.payload),
+			})
+			if (err != nil) != tt.expectError {
+				t.Errorf("ValidateJob() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cto-up/cron-lib/pkg/utils"
+)
+
+// HTTPJobType is the job type of HTTP jobs, registered with
+// jm.RegisterJobType(cron.HTTPJobType, jm.HTTPJobFactory)
+const HTTPJobType = "http"
+
+// defaultHTTPJobTimeout bounds the requests of HTTP jobs without a timeout
+const defaultHTTPJobTimeout = 30 * time.Second
+
+// maxHTTPResponseBody is the number of bytes of the response body recorded in the audit log
+const maxHTTPResponseBody = 4096
+
+// maxHTTPRedirects is the number of redirects followed by the HTTP jobs built by HTTPJobFactory,
+// the same as http.DefaultClient
+const maxHTTPRedirects = 10
+
+// HTTPJobConfig describes the request performed by an HTTP job.
+// It is also the payload of HTTP jobs defined through the API or scheduled once.
+type HTTPJobConfig struct {
+	// Method is the HTTP method of the request, GET when empty
+	Method string `json:"method,omitempty"`
+
+	// URL is the absolute http or https URL of the request
+	URL string `json:"url"`
+
+	// Headers are added to the request
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Body is the body of the request, none when empty
+	Body string `json:"body,omitempty"`
+
+	// ExpectedStatuses are the status codes of a successful run, any 2xx status when empty
+	ExpectedStatuses []int `json:"expected_statuses,omitempty"`
+
+	// TimeoutSeconds is the maximum duration of the request, 30 seconds when zero
+	TimeoutSeconds int `json:"timeout_seconds,omitempty"`
+}
+
+// validate checks that the config describes a request that can be sent
+func (c HTTPJobConfig) validate() error {
+	parsed, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", c.URL, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid URL %q: an absolute http or https URL is expected", c.URL)
+	}
+	if c.TimeoutSeconds < 0 {
+		return errors.New("timeout_seconds must not be negative")
+	}
+	for _, status := range c.ExpectedStatuses {
+		if status < 100 || status > 599 {
+			return fmt.Errorf("invalid expected status %d", status)
+		}
+	}
+	return nil
+}
+
+// HTTPJob is a job calling an HTTP endpoint on its schedule. The status and the beginning of the
+// body of the response are recorded in the audit log, and a status other than the expected ones
+// fails the run.
+type HTTPJob struct {
+	name     string
+	tenantID string
+	schedule string
+	config   HTTPJobConfig
+	client   *http.Client
+}
+
+// NewHTTPJob returns an HTTP job performing the request described by config, to register with RegisterJob.
+// Jobs built in code may call any host, unlike the jobs built by HTTPJobFactory.
+func NewHTTPJob(name string, tenantID string, schedule string, config HTTPJobConfig) (*HTTPJob, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid HTTP job %s: %w", name, err)
+	}
+	return &HTTPJob{
+		name:     name,
+		tenantID: tenantID,
+		schedule: schedule,
+		config:   config,
+		client:   http.DefaultClient,
+	}, nil
+}
+
+// HTTPJobFactory builds HTTP jobs from their spec, whose payload is an HTTPJobConfig.
+// As tenants define these jobs through the API, their URL must target one of the hosts allowed with
+// WithHTTPAllowedHosts, and so must the redirects they follow. None is allowed by default.
+func (jm *JobManager) HTTPJobFactory(spec JobSpec) (Job, error) {
+	var config HTTPJobConfig
+	if len(spec.Payload) == 0 {
+		return nil, fmt.Errorf("invalid HTTP job %s: payload is required", spec.Name)
+	}
+	if err := json.Unmarshal(spec.Payload, &config); err != nil {
+		return nil, fmt.Errorf("invalid HTTP job %s: %w", spec.Name, err)
+	}
+	job, err := NewHTTPJob(spec.Name, spec.TenantID, spec.Schedule, config)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedHosts := jm.options.httpAllowedHosts
+	// validate already parsed the URL
+	target, _ := url.Parse(config.URL)
+	if !isHTTPHostAllowed(allowedHosts, target) {
+		return nil, fmt.Errorf("invalid HTTP job %s: host %s is not allowed", spec.Name, target.Hostname())
+	}
+	job.client = &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxHTTPRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxHTTPRedirects)
+			}
+			if !isHTTPHostAllowed(allowedHosts, req.URL) {
+				return fmt.Errorf("redirect to host %s is not allowed", req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+	return job, nil
+}
+
+// isHTTPHostAllowed reports whether the host of the URL is one of the allowed hosts, or a subdomain
+// of an allowed "*." domain
+func isHTTPHostAllowed(allowedHosts []string, target *url.URL) bool {
+	host := strings.ToLower(target.Hostname())
+	if host == "" {
+		return false
+	}
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if domain, isWildcard := strings.CutPrefix(allowed, "*."); isWildcard {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *HTTPJob) Name() string {
+	return j.name
+}
+
+func (j *HTTPJob) Lock() string {
+	return j.name
+}
+
+func (j *HTTPJob) TenantID() string {
+	return j.tenantID
+}
+
+func (j *HTTPJob) Schedule() string {
+	return j.schedule
+}
+
+func (j *HTTPJob) NextRunTime() time.Time {
+	next, _ := utils.NextRunTime(j.schedule)
+	return next
+}
+
+func (j *HTTPJob) IsLongRunning() bool {
+	return false
+}
+
+// Timeout makes HTTPJob a TimeoutJob, so a request exceeding it is recorded as "timed_out"
+func (j *HTTPJob) Timeout() time.Duration {
+	if j.config.TimeoutSeconds > 0 {
+		return time.Duration(j.config.TimeoutSeconds) * time.Second
+	}
+	return defaultHTTPJobTimeout
+}
+
+func (j *HTTPJob) Run(ctx context.Context) error {
+	_, err := j.RunWithResult(ctx)
+	return err
+}
+
+// RunWithResult performs the request. The result holds the status and the response body, truncated
+// to its first 4 KiB, which are also recorded when the status is unexpected.
+func (j *HTTPJob) RunWithResult(ctx context.Context) (JobResult, error) {
+	method := strings.ToUpper(j.config.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if j.config.Body != "" {
+		body = strings.NewReader(j.config.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, j.config.URL, body)
+	if err != nil {
+		return JobResult{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	for key, value := range j.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return JobResult{}, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read one more byte than recorded to tell whether the body was truncated
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBody+1))
+	if err != nil {
+		return JobResult{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	truncated := len(responseBody) > maxHTTPResponseBody
+	if truncated {
+		responseBody = responseBody[:maxHTTPResponseBody]
+	}
+
+	result := JobResult{
+		Output: fmt.Sprintf("%s %s returned %s", method, j.config.URL, resp.Status),
+		Data: map[string]interface{}{
+			"status_code":    resp.StatusCode,
+			"body":           strings.ToValidUTF8(string(responseBody), ""),
+			"body_truncated": truncated,
+			"duration_ms":    time.Since(start).Milliseconds(),
+		},
+	}
+	if !j.isExpectedStatus(resp.StatusCode) {
+		return result, fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	}
+	return result, nil
+}
+
+// isExpectedStatus reports whether the status code is one of a successful run
+func (j *HTTPJob) isExpectedStatus(status int) bool {
+	if len(j.config.ExpectedStatuses) == 0 {
+		return status >= 200 && status < 300
+	}
+	return slices.Contains(j.config.ExpectedStatuses, status)
+}
@@ -0,0 +1,252 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(r.Method + " " + r.Header.Get("X-Token") + " " + string(body)))
+		case "/accepted":
+			w.WriteHeader(http.StatusAccepted)
+		case "/large":
+			w.Write([]byte(strings.Repeat("a", maxHTTPResponseBody+10)))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("boom"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		config        HTTPJobConfig
+		timeout       time.Duration
+		expectError   bool
+		expectStatus  int
+		expectBody    string
+		expectTrunc   bool
+		expectTimeout bool
+	}{
+		{
+			name: "Request with method, headers and body",
+			config: HTTPJobConfig{
+				Method:  "post",
+				URL:     server.URL + "/echo",
+				Headers: map[string]string{"X-Token": "secret"},
+				Body:    `{"a":1}`,
+			},
+			expectStatus: http.StatusOK,
+			expectBody:   `POST secret {"a":1}`,
+		},
+		{
+			name:         "Server error fails the run",
+			config:       HTTPJobConfig{URL: server.URL + "/fail"},
+			expectError:  true,
+			expectStatus: http.StatusInternalServerError,
+			expectBody:   "boom",
+		},
+		{
+			name:         "Expected status",
+			config:       HTTPJobConfig{URL: server.URL + "/fail", ExpectedStatuses: []int{http.StatusInternalServerError}},
+			expectStatus: http.StatusInternalServerError,
+			expectBody:   "boom",
+		},
+		{
+			name:         "Status outside the expected ones",
+			config:       HTTPJobConfig{URL: server.URL + "/accepted", ExpectedStatuses: []int{http.StatusOK}},
+			expectError:  true,
+			expectStatus: http.StatusAccepted,
+		},
+		{
+			name:         "Large body is truncated",
+			config:       HTTPJobConfig{URL: server.URL + "/large"},
+			expectStatus: http.StatusOK,
+			expectBody:   strings.Repeat("a", maxHTTPResponseBody),
+			expectTrunc:  true,
+		},
+		{
+			name:          "Request exceeding the timeout",
+			config:        HTTPJobConfig{URL: server.URL + "/slow"},
+			timeout:       50 * time.Millisecond,
+			expectError:   true,
+			expectTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := NewHTTPJob("webhook", "tenant", "@every 1m", tt.config)
+			if err != nil {
+				t.Fatalf("NewHTTPJob() error = %v", err)
+			}
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			result, err := job.RunWithResult(ctx)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RunWithResult() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectTimeout {
+				if len(result.Data) != 0 {
+					t.Errorf("RunWithResult() result = %v, want no result", result.Data)
+				}
+				return
+			}
+			if status := result.Data["status_code"]; status != tt.expectStatus {
+				t.Errorf("status_code = %v, want %d", status, tt.expectStatus)
+			}
+			if body := result.Data["body"]; body != tt.expectBody {
+				t.Errorf("body = %q, want %q", body, tt.expectBody)
+			}
+			if truncated := result.Data["body_truncated"]; truncated != tt.expectTrunc {
+				t.Errorf("body_truncated = %v, want %v", truncated, tt.expectTrunc)
+			}
+		})
+	}
+}
+
+func TestHTTPJobFactory(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     string
+		expectError bool
+	}{
+		{
+			name:    "Valid payload",
+			payload: `{"method":"POST","url":"https://example.com/hook","expected_statuses":[200,204],"timeout_seconds":5}`,
+		},
+		{
+			name:        "Missing payload",
+			expectError: true,
+		},
+		{
+			name:        "Relative URL",
+			payload:     `{"url":"/hook"}`,
+			expectError: true,
+		},
+		{
+			name:        "Unsupported scheme",
+			payload:     `{"url":"ftp://example.com/file"}`,
+			expectError: true,
+		},
+		{
+			name:        "Invalid expected status",
+			payload:     `{"url":"https://example.com/hook","expected_statuses":[42]}`,
+			expectError: true,
+		},
+		{
+			name:        "Host not allowed",
+			payload:     `{"url":"http://169.254.169.254/latest/meta-data"}`,
+			expectError: true,
+		},
+		{
+			name:    "Subdomain of an allowed domain",
+			payload: `{"url":"https://hooks.internal.example.org/reindex","timeout_seconds":5}`,
+		},
+		{
+			name:        "Allowed domain itself not covered by its wildcard",
+			payload:     `{"url":"https://internal.example.org/reindex"}`,
+			expectError: true,
+		},
+	}
+
+	jm := &JobManager{options: newJobManagerOptions(WithHTTPAllowedHosts("EXAMPLE.com", "*.internal.example.org"))}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := JobSpec{Name: "webhook", TenantID: "tenant", Schedule: "@hourly"}
+			if tt.payload != "" {
+				spec.Payload = json.RawMessage(tt.payload)
+			}
+
+			job, err := jm.HTTPJobFactory(spec)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("HTTPJobFactory() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil {
+				return
+			}
+			if job.Name() != spec.Name || job.TenantID() != spec.TenantID || job.Schedule() != spec.Schedule {
+				t.Errorf("HTTPJobFactory() built %s (tenant %s, schedule %s)", job.Name(), job.TenantID(), job.Schedule())
+			}
+			if timeout := job.(*HTTPJob).Timeout(); timeout != 5*time.Second {
+				t.Errorf("Timeout() = %s, want 5s", timeout)
+			}
+		})
+	}
+}
+
+func TestHTTPJobFactoryRedirects(t *testing.T) {
+	// Serves the same handler under two hosts, only 127.0.0.1 being allowed
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/allowed":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/other-host":
+			http.Redirect(w, r, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)+"/ok", http.StatusFound)
+		case "/ok":
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	jm := &JobManager{options: newJobManagerOptions(WithHTTPAllowedHosts("127.0.0.1"))}
+
+	tests := []struct {
+		name        string
+		path        string
+		expectError bool
+	}{
+		{
+			name: "Redirect to an allowed host",
+			path: "/allowed",
+		},
+		{
+			name:        "Redirect to a host not allowed",
+			path:        "/other-host",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := jm.HTTPJobFactory(JobSpec{
+				Name:     "webhook",
+				TenantID: "tenant",
+				Schedule: "@hourly",
+				Payload:  json.RawMessage(`{"url":"` + server.URL + tt.path + `"}`),
+			})
+			if err != nil {
+				t.Fatalf("HTTPJobFactory() error = %v", err)
+			}
+
+			result, err := job.(*HTTPJob).RunWithResult(context.Background())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RunWithResult() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !tt.expectError && result.Data["body"] != "ok" {
+				t.Errorf("body = %q, want %q", result.Data["body"], "ok")
+			}
+		})
+	}
+}
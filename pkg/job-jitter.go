@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// JitterJob is implemented by jobs starting after a random delay, e.g. to avoid hitting a rate-limited
+// API at the same time as other clients. Unlike hashed schedules, the delay changes on every run.
+type JitterJob interface {
+	Job
+
+	// MaxJitter returns the maximum random delay before a run starts. Zero means no delay.
+	MaxJitter() time.Duration
+}
+
+// maxJitter caps the jitter of jobs, so that it fits in the jitter_ms column of the audit log (about 24.8 days)
+const maxJitter = time.Duration(math.MaxInt32) * time.Millisecond
+
+// maxJitterOf returns the maximum jitter of the job, zero by default and at most maxJitter
+func maxJitterOf(job Job) time.Duration {
+	if jitterJob, ok := job.(JitterJob); ok {
+		return min(max(jitterJob.MaxJitter(), 0), maxJitter)
+	}
+	return 0
+}
+
+// waitJitter waits a random delay of up to the maximum jitter of the job, which is recorded in the
+// audit log of the run. The job locks are held meanwhile, so the wait stops when ctx is canceled,
+// returning its error.
+func (jm *JobManager) waitJitter(ctx context.Context, job Job, auditLogID uuid.UUID) error {
+	jobMaxJitter := maxJitterOf(job)
+	if jobMaxJitter <= 0 {
+		return nil
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(jobMaxJitter) + 1))
+	log.Printf("Delaying job %s (tenant %s) by %s", job.Name(), job.TenantID(), jitter)
+	jm.recordJitter(auditLogID, jitter, job.TenantID())
+
+	timer := time.NewTimer(jitter)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recordJitter stores the random delay of a run in its audit log, unless the audit log could not be created
+func (jm *JobManager) recordJitter(auditLogID uuid.UUID, jitter time.Duration, tenantID string) {
+	if auditLogID == uuid.Nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := jm.store.UpdateJobAuditLogJitter(ctx, repository.UpdateJobAuditLogJitterParams{
+		ID:       auditLogID,
+		JitterMs: pgtype.Int4{Int32: int32(jitter.Milliseconds()), Valid: true},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		log.Printf("Error recording jitter in job audit log %s: %v", auditLogID, err)
+	}
+}
@@ -0,0 +1,105 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jitterTestJob is a job starting after a random delay of up to maxJitter
+type jitterTestJob struct {
+	Job
+	maxJitter time.Duration
+}
+
+func (j *jitterTestJob) Name() string             { return "report" }
+func (j *jitterTestJob) TenantID() string         { return "tenant" }
+func (j *jitterTestJob) MaxJitter() time.Duration { return j.maxJitter }
+
+func TestMaxJitterOf(t *testing.T) {
+	tests := []struct {
+		name            string
+		job             Job
+		expectMaxJitter time.Duration
+	}{
+		{
+			name: "Job without jitter",
+			job:  &locatedTestJob{schedule: "@hourly"},
+		},
+		{
+			name: "Zero jitter",
+			job:  &jitterTestJob{},
+		},
+		{
+			name: "Negative jitter",
+			job:  &jitterTestJob{maxJitter: -time.Minute},
+		},
+		{
+			name:            "Jitter",
+			job:             &jitterTestJob{maxJitter: 5 * time.Minute},
+			expectMaxJitter: 5 * time.Minute,
+		},
+		{
+			name:            "Jitter at the cap",
+			job:             &jitterTestJob{maxJitter: maxJitter},
+			expectMaxJitter: maxJitter,
+		},
+		{
+			name:            "Jitter above the cap",
+			job:             &jitterTestJob{maxJitter: time.Duration(math.MaxInt64)},
+			expectMaxJitter: maxJitter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if maxJitter := maxJitterOf(tt.job); maxJitter != tt.expectMaxJitter {
+				t.Errorf("maxJitterOf() = %s, want %s", maxJitter, tt.expectMaxJitter)
+			}
+		})
+	}
+}
+
+func TestWaitJitter(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxJitter   time.Duration
+		cancel      bool
+		expectError error
+	}{
+		{
+			name: "No jitter",
+		},
+		{
+			name:      "Short jitter",
+			maxJitter: time.Millisecond,
+		},
+		{
+			name:        "Wait stopped by the context",
+			maxJitter:   time.Duration(math.MaxInt64),
+			cancel:      true,
+			expectError: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				time.AfterFunc(10*time.Millisecond, cancel)
+			}
+
+			// Without audit log, the jitter is not recorded
+			jm := &JobManager{}
+			err := jm.waitJitter(ctx, &jitterTestJob{maxJitter: tt.maxJitter}, uuid.Nil)
+			if !errors.Is(err, tt.expectError) {
+				t.Errorf("waitJitter() error = %v, want %v", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// errAdvisoryLockLost is the error recorded for a run whose advisory lock was lost
+var errAdvisoryLockLost = errors.New("advisory lock lost during execution")
+
+// advisoryLock is a session-level PostgreSQL advisory lock pinned to a dedicated pooled connection.
+// The lock is released on the connection that acquired it, and lost when that connection is lost.
+type advisoryLock struct {
+	conn     *pgxpool.Conn
+	queries  *repository.Queries
+	lockIDs  []int64
+	mutex    sync.Mutex    // Serializes the connection checks and the release
+	released bool          // Set once the lock is released, protected by mutex
+	lost     chan struct{} // Closed when the connection holding the lock is lost
+	lostOnce sync.Once
+	stop     chan struct{} // Stops the connection checks
+}
+
+// DefaultLockNamespace is the namespace of the advisory lock IDs when WithLockNamespace is not used
+const DefaultLockNamespace = "cron-lib"
+
+// jobLockIDs returns the advisory lock IDs to take for a job lock: the namespaced 64-bit ID,
+// followed by the legacy 32-bit ID while legacy lock IDs are enabled
+func (jm *JobManager) jobLockIDs(lock string, tenantID string) []int64 {
+	lockIDs := []int64{jobLockID(jm.options.lockNamespace, lock, tenantID)}
+	if jm.options.legacyLockIDs {
+		lockIDs = append(lockIDs, int64(jobLockToLockID(lock, tenantID)))
+	}
+	return lockIDs
+}
+
+// jobLockID converts a job lock and tenant ID to a 64-bit advisory lock ID.
+// The namespace keeps the IDs apart from the advisory locks of other libraries using the same database.
+func jobLockID(namespace string, lock string, tenantID string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(namespace))
+	hash.Write([]byte{0})
+	hash.Write([]byte(jobKey(lock, tenantID)))
+	return int64(hash.Sum64())
+}
+
+// acquireAdvisoryLock tries to take all the advisory locks on a connection taken from the pool.
+// It returns nil without error when one of the locks is held by another session.
+func (jm *JobManager) acquireAdvisoryLock(ctx context.Context, lockIDs ...int64) (*advisoryLock, error) {
+	conn, err := jm.store.ConnPool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &advisoryLock{
+		conn:    conn,
+		queries: repository.New(conn),
+		lost:    make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+
+	for _, lockID := range lockIDs {
+		acquired, err := lock.queries.TryAdvisoryLock(ctx, lockID)
+		if err != nil || !acquired {
+			// Give back the locks taken so far
+			lock.unlock()
+			conn.Release()
+			if err != nil {
+				return nil, fmt.Errorf("advisory lock %d: %w", lockID, err)
+			}
+			return nil, nil
+		}
+		lock.lockIDs = append(lock.lockIDs, lockID)
+	}
+
+	go lock.watch(jm.options.lockCheckInterval)
+	return lock, nil
+}
+
+// Lost returns a channel closed when the lock is lost because its connection is gone
+func (l *advisoryLock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// IsLost reports whether the lock was lost
+func (l *advisoryLock) IsLost() bool {
+	select {
+	case <-l.lost:
+		return true
+	default:
+		return false
+	}
+}
+
+// watch checks the connection holding the lock until the lock is released or lost
+func (l *advisoryLock) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !l.check() {
+				l.markLost()
+				return
+			}
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// check pings the connection holding the lock. A session-level lock is released by
+// PostgreSQL when its session ends, so a failed ping means the lock can no longer be trusted.
+func (l *advisoryLock) check() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.released {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := l.conn.Ping(ctx); err != nil {
+		log.Printf("Connection holding advisory locks %v lost: %v", l.lockIDs, err)
+		return false
+	}
+	return true
+}
+
+// markLost closes the lost channel once
+func (l *advisoryLock) markLost() {
+	l.lostOnce.Do(func() {
+		close(l.lost)
+	})
+}
+
+// release unlocks the advisory locks and gives the connection back to the pool
+func (l *advisoryLock) release() {
+	close(l.stop)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.released {
+		return
+	}
+	l.released = true
+
+	l.unlock()
+
+	// The pool destroys closed connections instead of reusing them
+	l.conn.Release()
+}
+
+// unlock releases the advisory locks taken so far. When an unlock fails, the connection
+// is closed so PostgreSQL drops the remaining locks with the session.
+func (l *advisoryLock) unlock() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, lockID := range l.lockIDs {
+		released, err := l.queries.ReleaseAdvisoryLock(ctx, lockID)
+		if err != nil {
+			log.Printf("Error releasing advisory lock %d, closing its connection: %v", lockID, err)
+			l.conn.Conn().Close(ctx)
+			return
+		}
+		if !released {
+			log.Printf("Warning: advisory lock %d was not held by its connection", lockID)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cron
+
+import "testing"
+
+func TestJobLockID(t *testing.T) {
+	tests := []struct {
+		name        string
+		namespace   string
+		lock        string
+		tenantID    string
+		other       [3]string // Namespace, lock and tenant ID of the lock compared with
+		expectEqual bool
+	}{
+		{
+			name:        "Same lock in the same namespace",
+			namespace:   DefaultLockNamespace,
+			lock:        "report",
+			tenantID:    "tenant-a",
+			other:       [3]string{DefaultLockNamespace, "report", "tenant-a"},
+			expectEqual: true,
+		},
+		{
+			name:      "Same lock in another namespace",
+			namespace: DefaultLockNamespace,
+			lock:      "report",
+			tenantID:  "tenant-a",
+			other:     [3]string{"other-app", "report", "tenant-a"},
+		},
+		{
+			name:      "Same lock for another tenant",
+			namespace: DefaultLockNamespace,
+			lock:      "report",
+			tenantID:  "tenant-a",
+			other:     [3]string{DefaultLockNamespace, "report", "tenant-b"},
+		},
+		{
+			name:      "Another lock for the same tenant",
+			namespace: DefaultLockNamespace,
+			lock:      "report",
+			tenantID:  "tenant-a",
+			other:     [3]string{DefaultLockNamespace, "import", "tenant-a"},
+		},
+		{
+			name:      "Namespace and lock not mixed up",
+			namespace: "ab",
+			lock:      "c",
+			tenantID:  "tenant-a",
+			other:     [3]string{"a", "bc", "tenant-a"},
+		},
+		{
+			name:      "Empty namespace",
+			namespace: "",
+			lock:      "report",
+			tenantID:  "tenant-a",
+			other:     [3]string{DefaultLockNamespace, "report", "tenant-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := jobLockID(tt.namespace, tt.lock, tt.tenantID)
+			if id != jobLockID(tt.namespace, tt.lock, tt.tenantID) {
+				t.Fatalf("jobLockID(%q, %q, %q) is not stable", tt.namespace, tt.lock, tt.tenantID)
+			}
+
+			other := jobLockID(tt.other[0], tt.other[1], tt.other[2])
+			if (id == other) != tt.expectEqual {
+				t.Errorf("jobLockID(%q, %q, %q) = %d, jobLockID(%q, %q, %q) = %d, expectEqual %v",
+					tt.namespace, tt.lock, tt.tenantID, id, tt.other[0], tt.other[1], tt.other[2], other, tt.expectEqual)
+			}
+		})
+	}
+}
+
+func TestJobLockIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []JobManagerOption
+		expectLockIDs []int64
+	}{
+		{
+			name: "Default namespace with legacy lock ID",
+			expectLockIDs: []int64{
+				jobLockID(DefaultLockNamespace, "report", "tenant-a"),
+				int64(jobLockToLockID("report", "tenant-a")),
+			},
+		},
+		{
+			name:          "Custom namespace without legacy lock ID",
+			opts:          []JobManagerOption{WithLockNamespace("other-app"), WithLegacyLockIDs(false)},
+			expectLockIDs: []int64{jobLockID("other-app", "report", "tenant-a")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jm := &JobManager{options: newJobManagerOptions(tt.opts...)}
+			lockIDs := jm.jobLockIDs("report", "tenant-a")
+			if len(lockIDs) != len(tt.expectLockIDs) {
+				t.Fatalf("jobLockIDs() = %v, want %v", lockIDs, tt.expectLockIDs)
+			}
+			for i := range lockIDs {
+				if lockIDs[i] != tt.expectLockIDs[i] {
+					t.Errorf("jobLockIDs()[%d] = %d, want %d", i, lockIDs[i], tt.expectLockIDs[i])
+				}
+			}
+		})
+	}
+}
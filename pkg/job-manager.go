@@ -2,6 +2,8 @@ package cron
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/cto-up/cron-lib/pkg/db"
 	"github.com/cto-up/cron-lib/pkg/db/repository"
+	"github.com/cto-up/cron-lib/pkg/utils"
 )
 
 // Job defines the interface that all scheduled jobs must implement
@@ -42,17 +45,78 @@ type Job interface {
 	IsLongRunning() bool
 }
 
+// JobResult is what a job reports about its run
+type JobResult struct {
+	// Output is a summary of the run, stored in the output of the audit log
+	Output string
+
+	// Data is a structured result (items processed, warnings...), stored as JSON in the audit log
+	Data map[string]interface{}
+}
+
+// JobWithResult is implemented by jobs reporting what they did.
+// RunWithResult is called instead of Run.
+type JobWithResult interface {
+	Job
+
+	// RunWithResult executes the job and returns its result, which is also recorded when the job fails
+	RunWithResult(ctx context.Context) (JobResult, error)
+}
+
+// TimeoutJob is implemented by jobs with a maximum execution time.
+// The run context is canceled when the timeout expires, and the run is recorded as "timed_out".
+type TimeoutJob interface {
+	Job
+
+	// Timeout returns the maximum duration of a run, or of each attempt when retried. Zero means no limit.
+	Timeout() time.Duration
+}
+
+// LocatedJob is implemented by jobs scheduled in a given time zone, e.g. to follow its daylight saving time.
+// Other jobs are scheduled in the time zone of the server.
+type LocatedJob interface {
+	Job
+
+	// Location returns the time zone the schedule of the job is evaluated in
+	Location() *time.Location
+}
+
+// ErrJobTimedOut is the error of a run that exceeded the timeout of its job
+var ErrJobTimedOut = errors.New("job timed out")
+
 type JobManager struct {
 	cron          *cron.Cron
 	jobs          []Job
 	entryIDs      map[string]cron.EntryID // Map job identifiers to cron entry IDs
-	mutex         sync.Mutex              // Protect concurrent access to jobs and entryIDs
+	schedules     map[string]string       // Map job identifiers to the schedule their cron entry runs with
+	overrides     map[string]string       // Map job identifiers to schedule overrides set through the API
+	jobTypes      map[string]JobFactory   // Map job types to the factory building their jobs
+	defined       map[string]string       // Map identifiers of jobs defined through the API to the definition they were built from
+	sqlStatements map[string]SQLStatement // Map names of SQL statements to the statements SQL jobs may run
+	mutex         sync.Mutex              // Protect concurrent access to jobs, entryIDs, schedules, overrides, jobTypes, defined and sqlStatements
 	context       context.Context
 	store         *db.Store
-	instanceID    string        // Unique identifier for this application instance
-	isRunning     bool          // Track if scheduler is running
-	cleanupTicker *time.Ticker  // For periodic cleanup
-	stopCleanup   chan struct{} // Signal to stop cleanup routine
+	instanceID    string             // Unique identifier for this application instance
+	isRunning     bool               // Track if scheduler is running
+	cleanupTicker *time.Ticker       // For periodic cleanup
+	syncTicker    *time.Ticker       // For periodic sync of registrations
+	oneOffTicker  *time.Ticker       // For periodic claims of due one-off jobs
+	stopRoutines  chan struct{}      // Signal to stop cleanup and sync routines
+	stopListener  context.CancelFunc // Stop the run request listener
+	options       jobManagerOptions  // Timings set through JobManagerOption
+}
+
+// systemUserID is recorded as the user of scheduled runs in the audit log
+const systemUserID = "system"
+
+// jobExecution describes what triggered a job run
+type jobExecution struct {
+	requestID     string          // Request ID recorded in the audit log, generated when empty
+	userID        string          // User who triggered the run
+	scheduledTime time.Time       // Fire time the run belongs to, the start time when zero
+	payload       json.RawMessage // Payload of the run, the payload of the registration of the job when nil
+	workflowRunID uuid.UUID       // Workflow run the run belongs to, uuid.Nil when none
+	oneOffJobID   uuid.UUID       // One-off job the run belongs to, uuid.Nil when none
 }
 
 // Singleton instance and mutex for thread-safe initialization
@@ -70,30 +134,45 @@ func GetJobManager() *JobManager {
 	return instance
 }
 
-// InitJobManager initializes the singleton JobManager instance
-func InitJobManager(ctx context.Context, connPool *pgxpool.Pool) *JobManager {
+// InitJobManager initializes the singleton JobManager instance, a convenience wrapper around NewJobManager.
+// The options are only applied by the first call.
+func InitJobManager(ctx context.Context, connPool *pgxpool.Pool, opts ...JobManagerOption) *JobManager {
 	instanceMu.Lock()
 	defer instanceMu.Unlock()
 
 	instanceOnce.Do(func() {
-		instance = newJobManager(ctx, connPool)
+		instance = NewJobManager(ctx, connPool, opts...)
 		log.Printf("JobManager singleton initialized with instance ID: %s", instance.instanceID)
 	})
 
 	return instance
 }
 
-// newJobManager creates a new JobManager instance (private constructor)
-func newJobManager(ctx context.Context, connPool *pgxpool.Pool) *JobManager {
+// NewJobManager creates a new JobManager instance, independent from the singleton and
+// from other instances. Each instance has its own instance ID, scheduler and routines,
+// so several schedulers can run in one process, e.g. one per database.
+func NewJobManager(ctx context.Context, connPool *pgxpool.Pool, opts ...JobManagerOption) *JobManager {
 	instanceID := uuid.New().String() // Generate a unique ID for this instance
+	options := newJobManagerOptions(opts...)
+	if options.heartbeatInterval >= options.staleLockAfter {
+		log.Printf("Warning: heartbeat interval %s is not below stale lock delay %s, long-running jobs may run twice",
+			options.heartbeatInterval, options.staleLockAfter)
+	}
 	return &JobManager{
-		cron:       cron.New(cron.WithSeconds()),
-		jobs:       []Job{},
-		entryIDs:   make(map[string]cron.EntryID),
-		context:    ctx,
-		store:      db.NewStore(connPool, true),
-		instanceID: instanceID,
-		isRunning:  false,
+		cron:          cron.New(cron.WithParser(utils.ScheduleParser{})),
+		jobs:          []Job{},
+		entryIDs:      make(map[string]cron.EntryID),
+		schedules:     make(map[string]string),
+		overrides:     make(map[string]string),
+		jobTypes:      make(map[string]JobFactory),
+		defined:       make(map[string]string),
+		sqlStatements: make(map[string]SQLStatement),
+		context:       ctx,
+		store:         db.NewStore(connPool, true),
+		instanceID:    instanceID,
+		isRunning:     false,
+		stopRoutines:  make(chan struct{}),
+		options:       options,
 	}
 }
 
@@ -122,16 +201,25 @@ func (jm *JobManager) RegisterJob(job Job) {
 	jobName := job.Name()
 	tenantID := job.TenantID()
 	schedule := job.Schedule()
+	if normalized, err := utils.NormalizeSchedule(schedule); err == nil {
+		schedule = normalized
+	}
 	isLongRunning := job.IsLongRunning()
+	var timeZone pgtype.Text
+	if loc := locationOf(job); loc != nil {
+		timeZone = pgtype.Text{String: loc.String(), Valid: true}
+	}
 
 	// Register job in database
 	params := repository.UpsertRegisteredJobParams{
 		JobName:       jobName,
 		Schedule:      schedule,
 		IsLongRunning: isLongRunning,
-		IsEnabled:     true, // Default to enabled
+		IsEnabled:     true, // Default to enabled, only applied when the job is first registered
 		InstanceID:    jm.instanceID,
 		TenantID:      tenantID,
+		TimeZone:      timeZone,
+		ScheduleType:  utils.ScheduleTypeOf(schedule),
 	}
 
 	_, err := jm.store.UpsertRegisteredJob(ctx, params)
@@ -146,22 +234,56 @@ func (jm *JobManager) RegisterJob(job Job) {
 	}
 }
 
-// scheduleJob adds a job to the cron scheduler
+// scheduleJob adds a job to the cron scheduler, replacing its previous entry if any
 func (jm *JobManager) scheduleJob(job Job) {
-	schedule := job.Schedule()
-	entryID, err := jm.cron.AddFunc(schedule, func() {
-		jm.executeJobWithLock(job)
-	})
-
+	key := jobKey(job.Name(), job.TenantID())
+	schedule := jm.effectiveSchedule(job)
+	parsedSchedule, err := parseJobSchedule(job, schedule)
 	if err != nil {
-		log.Printf("Failed to schedule job %s: %v", job.Name(), err)
+		log.Printf("Failed to schedule job %s with schedule %q: %v", job.Name(), schedule, err)
 		return
 	}
+	entryID := jm.cron.Schedule(parsedSchedule, newScheduledRun(jm, job, parsedSchedule, time.Now()))
+
+	// Only remove the previous entry once the new one is in place
+	if previousID, exists := jm.entryIDs[key]; exists {
+		jm.cron.Remove(previousID)
+	}
 
 	// Store the entry ID for later removal if needed
-	key := jobKey(job.Name(), job.TenantID())
 	jm.entryIDs[key] = entryID
-	log.Printf("Job %s for tenant %s scheduled with ID %v", job.Name(), job.TenantID(), entryID)
+	jm.schedules[key] = schedule
+	log.Printf("Job %s for tenant %s scheduled with ID %v (%s)", job.Name(), job.TenantID(), entryID, schedule)
+}
+
+// effectiveSchedule returns the schedule override of the job if any, or the schedule defined in code.
+// The caller must hold jm.mutex.
+func (jm *JobManager) effectiveSchedule(job Job) string {
+	if override, exists := jm.overrides[jobKey(job.Name(), job.TenantID())]; exists {
+		return override
+	}
+	return job.Schedule()
+}
+
+// nextRunTime returns when the job should run next according to its effective schedule
+func (jm *JobManager) nextRunTime(job Job) time.Time {
+	jm.mutex.Lock()
+	_, overridden := jm.overrides[jobKey(job.Name(), job.TenantID())]
+	schedule := jm.effectiveSchedule(job)
+	jm.mutex.Unlock()
+
+	// The job computes its own next run time, unless its schedule is overridden, spread with H tokens
+	// or evaluated in a time zone of its own, which NextRunTime may not follow
+	if !overridden && !utils.IsHashedSchedule(schedule) && locationOf(job) == nil {
+		return job.NextRunTime()
+	}
+
+	parsedSchedule, err := parseJobSchedule(job, schedule)
+	if err != nil {
+		log.Printf("Error computing next run time of job %s (tenant %s): %v", job.Name(), job.TenantID(), err)
+		return time.Time{}
+	}
+	return parsedSchedule.Next(time.Now())
 }
 
 // UnregisterJob removes a job from the job manager
@@ -176,6 +298,7 @@ func (jm *JobManager) UnregisterJob(jobName string, tenantID string) {
 		if entryID, exists := jm.entryIDs[key]; exists {
 			jm.cron.Remove(entryID)
 			delete(jm.entryIDs, key)
+			delete(jm.schedules, key)
 			log.Printf("Removed job %s for tenant %s from running scheduler", jobName, tenantID)
 		}
 	}
@@ -209,6 +332,9 @@ func (jm *JobManager) UnregisterJob(jobName string, tenantID string) {
 
 // StartScheduler starts the cron scheduler with concurrency control
 func (jm *JobManager) StartScheduler() {
+	// Load schedule overrides so jobs are scheduled with them right away
+	jm.syncRegistrations()
+
 	jm.mutex.Lock()
 	defer jm.mutex.Unlock()
 
@@ -224,11 +350,18 @@ func (jm *JobManager) StartScheduler() {
 	}
 
 	// Start the cron scheduler
+	startedAt := time.Now()
 	jm.cron.Start()
 	jm.isRunning = true
 
+	// Catch up the runs missed while no instance was running
+	jm.catchUpMissedRuns(append([]Job(nil), jm.jobs...), startedAt)
+
 	// **START CLEANUP ROUTINE HERE**
 	jm.startCleanupRoutine()
+	jm.startSyncRoutine()
+	jm.startOneOffRoutine()
+	jm.startRunRequestListener()
 
 	log.Printf("Scheduler started with %d jobs", len(jm.jobs))
 }
@@ -236,20 +369,25 @@ func (jm *JobManager) StartScheduler() {
 // StopScheduler stops the cron scheduler
 func (jm *JobManager) StopScheduler() {
 	jm.mutex.Lock()
-	defer jm.mutex.Unlock()
-
 	if !jm.isRunning {
+		jm.mutex.Unlock()
 		return
 	}
 
-	// Stop the cleanup routine first
-	jm.stopCleanupRoutine()
+	// Stop the cleanup and sync routines first
+	jm.stopBackgroundRoutines()
 
-	// Stop the scheduler and wait for running jobs
+	// Stop the scheduler
 	ctx := jm.cron.Stop()
 
-	// Create a timeout for the shutdown
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Clear entry IDs as they're no longer valid
+	jm.entryIDs = make(map[string]cron.EntryID)
+	jm.schedules = make(map[string]string)
+	jm.isRunning = false
+	jm.mutex.Unlock()
+
+	// Wait for running jobs without holding jm.mutex, which they take e.g. to compute their next run time
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), jm.options.shutdownTimeout)
 	defer cancel()
 
 	// Wait for either the jobs to complete or the timeout to expire
@@ -257,19 +395,15 @@ func (jm *JobManager) StopScheduler() {
 	case <-ctx.Done():
 		log.Printf("All cron jobs completed gracefully")
 	case <-timeoutCtx.Done():
-		log.Printf("Warning: Cron job shutdown timed out after 30 seconds. Some jobs may not have completed.")
+		log.Printf("Warning: Cron job shutdown timed out after %s. Some jobs may not have completed.", jm.options.shutdownTimeout)
 	}
-
-	// Clear entry IDs as they're no longer valid
-	jm.entryIDs = make(map[string]cron.EntryID)
-	jm.isRunning = false
 	log.Printf("Scheduler stopped")
 }
 
 // **NEW: Start the cleanup routine**
 func (jm *JobManager) startCleanupRoutine() {
-	// Run cleanup every 5 minutes
-	jm.cleanupTicker = time.NewTicker(5 * time.Minute)
+	jm.cleanupTicker = time.NewTicker(jm.options.cleanupInterval)
+	stop := jm.stopRoutines
 
 	go func() {
 		// Run initial cleanup on startup
@@ -279,7 +413,7 @@ func (jm *JobManager) startCleanupRoutine() {
 			select {
 			case <-jm.cleanupTicker.C:
 				jm.cleanupStaleJobs()
-			case <-jm.stopCleanup:
+			case <-stop:
 				return
 			case <-jm.context.Done():
 				return
@@ -290,16 +424,109 @@ func (jm *JobManager) startCleanupRoutine() {
 	log.Printf("Cleanup routine started")
 }
 
-// **NEW: Stop the cleanup routine**
-func (jm *JobManager) stopCleanupRoutine() {
+// startSyncRoutine periodically applies changes made to cron_registered_jobs by other instances or the API
+func (jm *JobManager) startSyncRoutine() {
+	jm.syncTicker = time.NewTicker(jm.options.syncInterval)
+	stop := jm.stopRoutines
+
+	go func() {
+		for {
+			select {
+			case <-jm.syncTicker.C:
+				jm.syncRegistrations()
+				jm.processPendingRunRequests()
+				jm.failStuckWorkflowNodes()
+			case <-stop:
+				return
+			case <-jm.context.Done():
+				return
+			}
+		}
+	}()
+
+	log.Printf("Sync routine started")
+}
+
+// stopBackgroundRoutines stops the cleanup, sync, one-off and run request routines
+func (jm *JobManager) stopBackgroundRoutines() {
 	if jm.cleanupTicker != nil {
 		jm.cleanupTicker.Stop()
-		close(jm.stopCleanup)
-		jm.stopCleanup = make(chan struct{}) // Reset for next start
-		log.Printf("Cleanup routine stopped")
+	}
+	if jm.syncTicker != nil {
+		jm.syncTicker.Stop()
+	}
+	if jm.oneOffTicker != nil {
+		jm.oneOffTicker.Stop()
+	}
+	if jm.stopListener != nil {
+		jm.stopListener()
+		jm.stopListener = nil
+	}
+	close(jm.stopRoutines)
+	jm.stopRoutines = make(chan struct{}) // Reset for next start
+	log.Printf("Cleanup, sync, one-off and run request routines stopped")
+}
+
+// syncRegistrations builds the jobs defined through the API, loads the schedule overrides
+// of our jobs and reschedules the jobs whose effective schedule changed
+func (jm *JobManager) syncRegistrations() {
+	jm.syncDefinedJobs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	jm.mutex.Lock()
+	tenantIDs := jm.tenantIDs()
+	jm.mutex.Unlock()
+
+	if len(tenantIDs) == 0 {
+		return
+	}
+
+	registeredJobs, err := jm.store.ListRegisteredJobsByTenants(ctx, tenantIDs)
+	if err != nil {
+		log.Printf("Error loading registered jobs: %v", err)
+		return
+	}
+
+	overrides := make(map[string]string)
+	for _, registeredJob := range registeredJobs {
+		if registeredJob.ScheduleOverride.Valid {
+			overrides[jobKey(registeredJob.JobName, registeredJob.TenantID)] = registeredJob.ScheduleOverride.String
+		}
+	}
+
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	jm.overrides = overrides
+	if !jm.isRunning {
+		return
+	}
+
+	for _, job := range jm.jobs {
+		key := jobKey(job.Name(), job.TenantID())
+		if jm.schedules[key] == jm.effectiveSchedule(job) {
+			continue
+		}
+		log.Printf("Schedule of job %s for tenant %s changed, rescheduling", job.Name(), job.TenantID())
+		jm.scheduleJob(job)
 	}
 }
 
+// tenantIDs returns the unique tenant IDs of the registered jobs. The caller must hold jm.mutex.
+func (jm *JobManager) tenantIDs() []string {
+	seen := make(map[string]bool)
+	tenantIDs := []string{}
+	for _, job := range jm.jobs {
+		if !seen[job.TenantID()] {
+			seen[job.TenantID()] = true
+			tenantIDs = append(tenantIDs, job.TenantID())
+		}
+	}
+	return tenantIDs
+}
+
 // **NEW: Cleanup stale jobs periodically**
 func (jm *JobManager) cleanupStaleJobs() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -307,16 +534,30 @@ func (jm *JobManager) cleanupStaleJobs() {
 
 	// Get unique tenant IDs from registered jobs
 	tenantIDs := make(map[string]bool)
+	touchParams := repository.TouchRegisteredJobsParams{}
 	jm.mutex.Lock()
 	for _, job := range jm.jobs {
 		tenantIDs[job.TenantID()] = true
+		touchParams.TenantIds = append(touchParams.TenantIds, job.TenantID())
+		touchParams.JobNames = append(touchParams.JobNames, job.Name())
 	}
 	jm.mutex.Unlock()
 
+	// Refresh our registrations so they are not removed as stale, which would
+	// also drop the is_enabled flag set by an operator
+	if len(touchParams.JobNames) > 0 {
+		if _, err := jm.store.TouchRegisteredJobs(ctx, touchParams); err != nil {
+			log.Printf("Error refreshing registered jobs: %v", err)
+		}
+	}
+
 	// Clean up stale locks for each tenant
 	totalCleaned := int64(0)
 	for tenantID := range tenantIDs {
-		result, err := jm.store.CleanupStaleLocks(ctx, tenantID)
+		result, err := jm.store.CleanupStaleLocks(ctx, repository.CleanupStaleLocksParams{
+			LockedBefore: time.Now().Add(-jm.options.staleCleanupAfter),
+			TenantID:     tenantID,
+		})
 		if err != nil {
 			log.Printf("Error cleaning up stale locks for tenant %s: %v", tenantID, err)
 			continue
@@ -331,6 +572,21 @@ func (jm *JobManager) cleanupStaleJobs() {
 	if totalCleaned > 0 {
 		log.Printf("Total stale locks cleaned up: %d", totalCleaned)
 	}
+
+	// Remove run requests that were never claimed or are long done
+	if _, err := jm.store.CleanupRunRequests(ctx, time.Now().Add(-jm.options.retention)); err != nil {
+		log.Printf("Error cleaning up run requests: %v", err)
+	}
+
+	// Remove the one-off jobs run long ago
+	if _, err := jm.store.CleanupOneOffJobs(ctx, time.Now().Add(-jm.options.retention)); err != nil {
+		log.Printf("Error cleaning up one-off jobs: %v", err)
+	}
+
+	// Remove the workflow runs started long ago, with their nodes
+	if _, err := jm.store.CleanupWorkflowRuns(ctx, time.Now().Add(-jm.options.retention)); err != nil {
+		log.Printf("Error cleaning up workflow runs: %v", err)
+	}
 }
 
 // **NEW: Update job heartbeat for long-running jobs**
@@ -349,7 +605,7 @@ func (jm *JobManager) updateJobHeartbeat(jobID uuid.UUID) {
 
 // **NEW: Start heartbeat routine for long-running jobs**
 func (jm *JobManager) startHeartbeat(jobID uuid.UUID, stopChan <-chan struct{}) {
-	ticker := time.NewTicker(2 * time.Minute) // Update every 2 minutes
+	ticker := time.NewTicker(jm.options.heartbeatInterval)
 	defer ticker.Stop()
 
 	for {
@@ -364,14 +620,19 @@ func (jm *JobManager) startHeartbeat(jobID uuid.UUID, stopChan <-chan struct{})
 	}
 }
 
-// executeJobWithLock handles the concurrency control logic
-func (jm *JobManager) executeJobWithLock(job Job) {
+// executeJobWithLock handles the concurrency control logic. It returns "completed" or "failed" once
+// the job ran, "deferred" when the run was suppressed and deferred, or "skipped" when the run did not
+// happen otherwise, e.g. because the job is disabled, suppressed or running in another instance.
+func (jm *JobManager) executeJobWithLock(job Job, execution jobExecution) (outcome string) {
 	jobName := job.Name()
 	lock := job.Lock()
 	tenantID := job.TenantID()
 
 	// Generate a request ID for tracking this job execution
-	requestID := uuid.New().String()
+	requestID := execution.requestID
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
 
 	// Create initial audit log entry
 	auditCtx, auditCancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -379,17 +640,23 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 
 	now := time.Now()
 	scheduledTime := pgtype.Timestamp{Time: now, Valid: true}
+	if !execution.scheduledTime.IsZero() {
+		// Stored without time zone, so in the same time zone as the start time
+		scheduledTime.Time = execution.scheduledTime.In(now.Location())
+	}
 	startTime := pgtype.Timestamp{Time: now, Valid: true}
 
 	// Create audit log with "started" status
 	auditParams := repository.CreateJobAuditLogParams{
-		UserID:        "system",
+		UserID:        execution.userID,
 		AppID:         jm.instanceID,
 		RequestID:     requestID,
 		JobName:       jobName,
 		ScheduledTime: scheduledTime,
 		StartTime:     startTime,
 		Status:        "started",
+		Attempt:       1,
+		WorkflowRunID: pgtype.UUID{Bytes: execution.workflowRunID, Valid: execution.workflowRunID != uuid.Nil},
 		TenantID:      tenantID,
 	}
 
@@ -399,15 +666,31 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		// Continue execution even if audit logging fails
 	}
 
-	// Use PostgreSQL advisory lock to prevent concurrent execution
-	lockID := int64(jobLockToLockID(lock, tenantID))
+	// Advance the workflows of the job once the run is over, including when it did not happen
+	outcome = "skipped"
+	defer func() {
+		jm.advanceWorkflows(job, execution, auditLog.ID, outcome)
+	}()
+
+	// Check the is_enabled flag before taking any lock, so a job disabled through
+	// the API stops running on every instance
+	isEnabled, payload := jm.registrationOf(jobName, tenantID)
+	if !isEnabled {
+		log.Printf("Job %s for tenant %s is disabled, skipping execution", jobName, tenantID)
+		errorMsg := "Job is disabled"
+		jm.updateAuditLogStatus(auditLog.ID, "disabled", nil, &errorMsg, tenantID)
+		return
+	}
+
+	// Use PostgreSQL advisory locks to prevent concurrent execution
+	lockIDs := jm.jobLockIDs(lock, tenantID)
 
 	// Try to acquire an advisory lock with timeout
-	ctx, cancel := context.WithTimeout(jm.context, 60*time.Second)
+	ctx, cancel := context.WithTimeout(jm.context, jm.options.lockTimeout)
 	defer cancel()
 
-	// Try to acquire advisory lock using sqlc
-	lockResult, err := jm.store.TryAdvisoryLock(ctx, lockID)
+	// The advisory lock is held on a dedicated connection for the whole run
+	advisoryLock, err := jm.acquireAdvisoryLock(ctx, lockIDs...)
 	if err != nil {
 		log.Printf("Error acquiring lock for job %s (tenant %s): %v", jobName, tenantID, err)
 		errorMsg := err.Error()
@@ -415,7 +698,7 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		return
 	}
 
-	if !lockResult {
+	if advisoryLock == nil {
 		log.Printf("Job %s for tenant %s is already running in another instance", jobName, tenantID)
 		errorMsg := "Job already running in another instance"
 		jm.updateAuditLogStatus(auditLog.ID, "skipped", nil, &errorMsg, tenantID)
@@ -423,19 +706,21 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 	}
 
 	// Ensure advisory lock is released even in case of panic
-	defer func() {
-		// Release advisory lock using sqlc
-		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer releaseCancel()
-
-		err := jm.store.ReleaseAdvisoryLock(releaseCtx, lockID)
-		if err != nil {
-			log.Printf("Error releasing lock for job %s (tenant %s): %v", jobName, tenantID, err)
+	defer advisoryLock.release()
+
+	// Scheduled runs are suppressed during the blackout windows and holidays of the tenant,
+	// runs requested through the API are not
+	if execution.userID == systemUserID {
+		if suppressed, deferred := jm.isRunSuppressed(job, execution, auditLog.ID, now); suppressed {
+			if deferred {
+				outcome = "deferred"
+			}
+			return
 		}
-	}()
+	}
 
 	// Try to acquire the job lock in the database
-	nextRunTime := job.NextRunTime()
+	nextRunTime := jm.nextRunTime(job)
 
 	// Acquire job lock in DB using sqlc
 	acquireParams := repository.AcquireJobLockInDBParams{
@@ -445,6 +730,7 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		Now:         now,
 		NextRunTime: nextRunTime,
 		InstanceID:  jm.instanceID,
+		StaleBefore: now.Add(-jm.options.staleLockAfter),
 	}
 
 	jobID, err := jm.store.AcquireJobLockInDB(ctx, acquireParams)
@@ -463,8 +749,10 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 	}
 
 	// **START HEARTBEAT FOR LONG-RUNNING JOBS**
+	// Jobs with retries or jitter also need it, as the job lock is held during the backoff and jitter delays
+	policy := retryPolicyOf(job)
 	var heartbeatStop chan struct{}
-	if job.IsLongRunning() {
+	if job.IsLongRunning() || policy.retries() || maxJitterOf(job) > 0 {
 		heartbeatStop = make(chan struct{})
 		go jm.startHeartbeat(jobID, heartbeatStop)
 		log.Printf("Started heartbeat for long-running job %s (tenant %s)", jobName, tenantID)
@@ -477,6 +765,9 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		}
 	}()
 
+	// Audit log of the current attempt
+	attemptLog := auditLog
+
 	// Add panic recovery to ensure job status is updated even if job panics
 	defer func() {
 		if r := recover(); r != nil {
@@ -493,15 +784,74 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 
 			// Update audit log with panic information
 			errorMsg := fmt.Sprintf("Panic: %v", r)
-			jm.updateAuditLogStatus(auditLog.ID, "failed", nil, &errorMsg, tenantID)
+			jm.updateAuditLogStatus(attemptLog.ID, "failed", nil, &errorMsg, tenantID)
+			outcome = "failed"
+		}
+	}()
+
+	// Cancel the job when its advisory lock is lost, as another instance may start it
+	runCtx, runCancel := context.WithCancel(jm.context)
+	defer runCancel()
+	go func() {
+		select {
+		case <-advisoryLock.Lost():
+			log.Printf("Advisory lock of job %s (tenant %s) lost, canceling the job", jobName, tenantID)
+			runCancel()
+		case <-runCtx.Done():
 		}
 	}()
 
-	// Execute the job
-	var output string
-	var jobErr error
+	// Deliver the payload of the run, read when the run started
+	if execution.payload != nil {
+		payload = execution.payload
+	}
+	jobCtx := withPayload(runCtx, payload)
+
+	// Execute the job after its random delay, if any, retrying failed attempts according to its retry policy
+	var result JobResult
+	jobErr := jm.waitJitter(runCtx, job, auditLog.ID)
+	if jobErr == nil {
+		jobErr = configureJob(job, payload)
+	}
+	if jobErr == nil {
+		run := jm.runChain(job)
+		for attempt := 1; ; attempt++ {
+			result, jobErr = run(jobCtx, job)
+			if jobErr == nil || runCtx.Err() != nil || !policy.shouldRetry(attempt, jobErr) {
+				break
+			}
+
+			delay := policy.backoff(attempt)
+			log.Printf("Attempt %d of job %s (tenant %s) failed, retrying in %s: %v", attempt, jobName, tenantID, delay, jobErr)
+			errorMsg := jobErr.Error()
+			jm.updateAuditLogResult(attemptLog.ID, "retrying", result, &errorMsg, tenantID)
+
+			select {
+			case <-time.After(delay):
+			case <-runCtx.Done():
+			}
+			if runCtx.Err() != nil {
+				break
+			}
+
+			// Record the next attempt, linked to the audit log of the first one
+			attemptLog = jm.createAttemptAuditLog(auditParams, auditLog.ID, attempt+1)
+		}
+	}
+	if advisoryLock.IsLost() {
+		if jobErr != nil {
+			jobErr = fmt.Errorf("%w: %v", errAdvisoryLockLost, jobErr)
+		} else {
+			jobErr = errAdvisoryLockLost
+		}
+	}
+
+	// The lock timeout only covers acquiring the locks, the job itself may run longer
+	cancel()
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if jobErr = job.Run(jm.context); jobErr != nil {
+	if jobErr != nil {
 		log.Printf("Error in job %s (tenant %s): %v", jobName, tenantID, jobErr)
 
 		// Update status to failed using sqlc
@@ -510,9 +860,14 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 			log.Printf("Error updating job status to failed: %v", err)
 		}
 
-		// Update audit log with error information
+		// Update audit log with error information and what the job reported
+		status := "failed"
+		if errors.Is(jobErr, ErrJobTimedOut) {
+			status = "timed_out"
+		}
 		errorMsg := jobErr.Error()
-		jm.updateAuditLogStatus(auditLog.ID, "failed", nil, &errorMsg, tenantID)
+		jm.updateAuditLogResult(attemptLog.ID, status, result, &errorMsg, tenantID)
+		outcome = "failed"
 	} else {
 		log.Printf("Job %s for tenant %s executed successfully", jobName, tenantID)
 
@@ -523,19 +878,28 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		}
 
 		// Update audit log with success information
-		output = "Job completed successfully"
-		jm.updateAuditLogStatus(auditLog.ID, "completed", &output, nil, tenantID)
+		if result.Output == "" {
+			result.Output = "Job completed successfully"
+		}
+		jm.updateAuditLogResult(attemptLog.ID, "completed", result, nil, tenantID)
+		outcome = "completed"
 	}
 
 	// Periodically clean up old completed tasks
 	if jobName == "system.cleanup" || now.Minute() == 0 { // Run on the hour or with dedicated cleanup job
-		_, err := jm.store.CleanupOldTasks(ctx, tenantID)
+		_, err := jm.store.CleanupOldTasks(ctx, repository.CleanupOldTasksParams{
+			UpdatedBefore: now.Add(-jm.options.retention),
+			TenantID:      tenantID,
+		})
 		if err != nil {
 			log.Printf("Error cleaning up old tasks for tenant %s: %v", tenantID, err)
 		}
 
 		// Clean up stale locks
-		result, err := jm.store.CleanupStaleLocks(ctx, tenantID)
+		result, err := jm.store.CleanupStaleLocks(ctx, repository.CleanupStaleLocksParams{
+			LockedBefore: now.Add(-jm.options.staleCleanupAfter),
+			TenantID:     tenantID,
+		})
 		if err != nil {
 			log.Printf("Error cleaning up stale locks for tenant %s: %v", tenantID, err)
 		} else if rowsAffected := result.RowsAffected(); rowsAffected > 0 {
@@ -543,17 +907,107 @@ func (jm *JobManager) executeJobWithLock(job Job) {
 		}
 
 		// Clean up stale registered jobs
-		result, err = jm.store.CleanupStaleRegisteredJobs(ctx, tenantID)
+		result, err = jm.store.CleanupStaleRegisteredJobs(ctx, repository.CleanupStaleRegisteredJobsParams{
+			RegisteredBefore: now.Add(-jm.options.registrationTTL),
+			TenantID:         tenantID,
+		})
 		if err != nil {
 			log.Printf("Error cleaning up stale registered jobs for tenant %s: %v", tenantID, err)
 		} else if rowsAffected := result.RowsAffected(); rowsAffected > 0 {
 			log.Printf("Cleaned up %d stale registered jobs for tenant %s", rowsAffected, tenantID)
 		}
 	}
+	return outcome
+}
+
+// createAttemptAuditLog records a retry of a run, linked to the audit log of its first attempt
+func (jm *JobManager) createAttemptAuditLog(params repository.CreateJobAuditLogParams, parentID uuid.UUID, attempt int) repository.CronJobAuditLog {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	params.StartTime = pgtype.Timestamp{Time: time.Now(), Valid: true}
+	params.Attempt = int32(attempt)
+	params.ParentID = pgtype.UUID{Bytes: parentID, Valid: parentID != uuid.Nil}
+
+	auditLog, err := jm.store.CreateJobAuditLog(ctx, params)
+	if err != nil {
+		log.Printf("Error creating audit log for attempt %d of job %s (tenant %s): %v", attempt, params.JobName, params.TenantID, err)
+	}
+	return auditLog
+}
+
+// registrationOf reads the is_enabled flag and the payload of the job from cron_registered_jobs.
+// A job without registration row, or whose row cannot be read, is considered enabled without payload.
+func (jm *JobManager) registrationOf(jobName string, tenantID string) (bool, json.RawMessage) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registeredJob, err := jm.store.GetRegisteredJobByName(ctx, repository.GetRegisteredJobByNameParams{
+		JobName:  jobName,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		if err.Error() != pgx.ErrNoRows.Error() {
+			log.Printf("Error reading registration of job %s (tenant %s): %v", jobName, tenantID, err)
+		}
+		return true, nil
+	}
+	return registeredJob.IsEnabled, registeredJob.Payload
+}
+
+// runAttempt executes one attempt of the job, within the timeout of the job if any
+func runAttempt(ctx context.Context, job Job) (JobResult, error) {
+	timeoutJob, ok := job.(TimeoutJob)
+	if !ok || timeoutJob.Timeout() <= 0 {
+		return runJob(ctx, job)
+	}
+
+	timeout := timeoutJob.Timeout()
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := runJob(attemptCtx, job)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("%w after %s: %v", ErrJobTimedOut, timeout, err)
+	}
+	return result, err
+}
+
+// runJob executes the job, collecting its result when it implements JobWithResult
+func runJob(ctx context.Context, job Job) (JobResult, error) {
+	if jobWithResult, ok := job.(JobWithResult); ok {
+		return jobWithResult.RunWithResult(ctx)
+	}
+	return JobResult{}, job.Run(ctx)
+}
+
+// updateAuditLogResult updates the job audit log with the final status and the result reported by the job
+func (jm *JobManager) updateAuditLogResult(auditLogID uuid.UUID, status string, result JobResult, errorMsg *string, tenantID string) {
+	var output *string
+	if result.Output != "" {
+		output = &result.Output
+	}
+
+	var data json.RawMessage
+	if len(result.Data) > 0 {
+		encoded, err := json.Marshal(result.Data)
+		if err != nil {
+			log.Printf("Error encoding result of job audit log %s: %v", auditLogID, err)
+		} else {
+			data = encoded
+		}
+	}
+
+	jm.updateAuditLog(auditLogID, status, output, errorMsg, data, tenantID)
 }
 
 // updateAuditLogStatus updates the job audit log with the final status
 func (jm *JobManager) updateAuditLogStatus(auditLogID uuid.UUID, status string, output *string, errorMsg *string, tenantID string) {
+	jm.updateAuditLog(auditLogID, status, output, errorMsg, nil, tenantID)
+}
+
+// updateAuditLog updates the job audit log with the final status, output, error and result
+func (jm *JobManager) updateAuditLog(auditLogID uuid.UUID, status string, output *string, errorMsg *string, result json.RawMessage, tenantID string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -578,6 +1032,7 @@ func (jm *JobManager) updateAuditLogStatus(auditLogID uuid.UUID, status string,
 		Status:   status,
 		Output:   outputText,
 		Error:    errorText,
+		Result:   result,
 		TenantID: tenantID,
 	}
 
@@ -592,7 +1047,8 @@ func jobKey(name, tenantID string) string {
 	return fmt.Sprintf("%s:%s", tenantID, name)
 }
 
-// jobLockToLockID converts a job name and tenant ID to a consistent lock ID
+// jobLockToLockID converts a job name and tenant ID to the legacy 32-bit lock ID.
+// It is only used while legacy lock IDs are enabled, see WithLegacyLockIDs.
 func jobLockToLockID(jobName, tenantID string) uint32 {
 	// Combine job name and tenant ID to create a unique key
 	key := jobKey(jobName, tenantID)
@@ -0,0 +1,45 @@
+package cron
+
+import "context"
+
+// RunFunc runs one attempt of a job and returns its result
+type RunFunc func(ctx context.Context, job Job) (JobResult, error)
+
+// Middleware wraps the run of a job, e.g. to add tracing, inject a tenant database context,
+// rate limit or check a feature flag. It calls next to run the job, or returns without calling it
+// to skip the attempt, whose error is then recorded in the audit log.
+// Middlewares run inside the job locks, heartbeat and audit log, once per attempt.
+type Middleware func(next RunFunc) RunFunc
+
+// MiddlewareJob is implemented by jobs with middlewares of their own,
+// applied inside the middlewares of the job manager
+type MiddlewareJob interface {
+	Job
+
+	// Middlewares returns the middlewares of the job, the first one being the outermost
+	Middlewares() []Middleware
+}
+
+// middlewaresOf returns the middlewares of the job, if any
+func middlewaresOf(job Job) []Middleware {
+	if middlewareJob, ok := job.(MiddlewareJob); ok {
+		return middlewareJob.Middlewares()
+	}
+	return nil
+}
+
+// chainMiddlewares wraps run with the middlewares, the first one being the outermost
+func chainMiddlewares(run RunFunc, middlewares ...Middleware) RunFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			run = middlewares[i](run)
+		}
+	}
+	return run
+}
+
+// runChain returns the run of the job wrapped by the middlewares of the job manager, then those of the job
+func (jm *JobManager) runChain(job Job) RunFunc {
+	run := chainMiddlewares(runAttempt, middlewaresOf(job)...)
+	return chainMiddlewares(run, jm.options.middlewares...)
+}
@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// middlewareTestJob records its runs and returns its middlewares
+type middlewareTestJob struct {
+	Job
+	middlewares []Middleware
+	calls       *[]string
+}
+
+func (j *middlewareTestJob) Run(ctx context.Context) error {
+	*j.calls = append(*j.calls, "job")
+	return nil
+}
+
+func (j *middlewareTestJob) Middlewares() []Middleware {
+	return j.middlewares
+}
+
+// recordingMiddleware records the calls around the run under the given name
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next RunFunc) RunFunc {
+		return func(ctx context.Context, job Job) (JobResult, error) {
+			*calls = append(*calls, name+" before")
+			result, err := next(ctx, job)
+			*calls = append(*calls, name+" after")
+			return result, err
+		}
+	}
+}
+
+func TestRunChain(t *testing.T) {
+	errSkipped := errors.New("feature disabled")
+	skipping := func(next RunFunc) RunFunc {
+		return func(ctx context.Context, job Job) (JobResult, error) {
+			return JobResult{}, errSkipped
+		}
+	}
+
+	tests := []struct {
+		name        string
+		global      func(calls *[]string) []Middleware
+		job         func(calls *[]string) []Middleware
+		expectCalls []string
+		expectError error
+	}{
+		{
+			name:        "No middleware",
+			expectCalls: []string{"job"},
+		},
+		{
+			name: "Global middlewares wrap the job middlewares",
+			global: func(calls *[]string) []Middleware {
+				return []Middleware{recordingMiddleware("global 1", calls), recordingMiddleware("global 2", calls)}
+			},
+			job: func(calls *[]string) []Middleware {
+				return []Middleware{recordingMiddleware("job", calls), nil}
+			},
+			expectCalls: []string{
+				"global 1 before", "global 2 before", "job before", "job", "job after", "global 2 after", "global 1 after",
+			},
+		},
+		{
+			name: "Middleware skipping the run",
+			global: func(calls *[]string) []Middleware {
+				return []Middleware{recordingMiddleware("global", calls)}
+			},
+			job: func(calls *[]string) []Middleware {
+				return []Middleware{skipping}
+			},
+			expectCalls: []string{"global before", "global after"},
+			expectError: errSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			var global []Middleware
+			if tt.global != nil {
+				global = tt.global(&calls)
+			}
+			job := &middlewareTestJob{calls: &calls}
+			if tt.job != nil {
+				job.middlewares = tt.job(&calls)
+			}
+
+			jm := &JobManager{options: newJobManagerOptions(WithMiddleware(global...))}
+			_, err := jm.runChain(job)(context.Background(), job)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("run error = %v, want %v", err, tt.expectError)
+			}
+			if !slices.Equal(calls, tt.expectCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.expectCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+	"github.com/cto-up/cron-lib/pkg/utils"
+)
+
+// MisfirePolicy defines what happens to the runs of a job missed while no instance was running
+type MisfirePolicy struct {
+	// MaxCatchUpRuns is the number of missed runs caught up when the scheduler starts, the latest ones first.
+	// Zero skips the missed runs.
+	MaxCatchUpRuns int
+}
+
+var (
+	// MisfireSkip skips the missed runs, the default
+	MisfireSkip = MisfirePolicy{}
+
+	// MisfireFireOnce runs the job once if any run was missed
+	MisfireFireOnce = MisfirePolicy{MaxCatchUpRuns: 1}
+)
+
+// MisfireFireAll runs the job for each missed run, up to maxRuns runs
+func MisfireFireAll(maxRuns int) MisfirePolicy {
+	return MisfirePolicy{MaxCatchUpRuns: maxRuns}
+}
+
+// MisfireJob is implemented by jobs whose runs missed during downtime are caught up
+type MisfireJob interface {
+	Job
+
+	// MisfirePolicy returns what happens to the missed runs of the job
+	MisfirePolicy() MisfirePolicy
+}
+
+// misfirePolicyOf returns the misfire policy of the job, MisfireSkip by default
+func misfirePolicyOf(job Job) MisfirePolicy {
+	if misfireJob, ok := job.(MisfireJob); ok {
+		return misfireJob.MisfirePolicy()
+	}
+	return MisfireSkip
+}
+
+// catchUpMissedRuns runs the jobs whose runs were missed before the scheduler started at startedAt,
+// according to their misfire policy
+func (jm *JobManager) catchUpMissedRuns(jobs []Job, startedAt time.Time) {
+	for _, job := range jobs {
+		if misfirePolicyOf(job).MaxCatchUpRuns <= 0 {
+			continue
+		}
+		go jm.catchUpJob(job, startedAt)
+	}
+}
+
+// catchUpJob detects the runs of the job missed before startedAt from the next execution time
+// stored in cron_jobs, claims them and runs them in order
+func (jm *JobManager) catchUpJob(job Job, startedAt time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	jobName := job.Name()
+	tenantID := job.TenantID()
+
+	storedJob, err := jm.store.GetJobByLock(ctx, repository.GetJobByLockParams{
+		TenantID: tenantID,
+		Lock:     job.Lock(),
+	})
+	if err != nil {
+		if err.Error() != pgx.ErrNoRows.Error() {
+			log.Printf("Error loading next execution time of job %s (tenant %s): %v", jobName, tenantID, err)
+		}
+		// Otherwise the job never ran, so no run was missed
+		return
+	}
+
+	expected := storedJob.NextExecutionTime
+	if !expected.Valid || !expected.Time.Before(startedAt) {
+		return
+	}
+
+	jm.mutex.Lock()
+	scheduleSpec := jm.effectiveSchedule(job)
+	jm.mutex.Unlock()
+
+	schedule, err := parseJobSchedule(job, scheduleSpec)
+	if err != nil {
+		log.Printf("Error parsing schedule of job %s (tenant %s): %v", jobName, tenantID, err)
+		return
+	}
+
+	missedRunTimes := utils.MissedRunTimes(schedule, expected.Time, startedAt, misfirePolicyOf(job).MaxCatchUpRuns)
+	if len(missedRunTimes) == 0 {
+		return
+	}
+
+	// Only the instance moving the next execution time catches up, other instances starting
+	// at the same time see a different value
+	result, err := jm.store.ClaimMissedRuns(ctx, repository.ClaimMissedRunsParams{
+		NextExecutionTime:         schedule.Next(startedAt),
+		TenantID:                  tenantID,
+		Lock:                      job.Lock(),
+		ExpectedNextExecutionTime: expected.Time,
+	})
+	if err != nil {
+		log.Printf("Error claiming missed runs of job %s (tenant %s): %v", jobName, tenantID, err)
+		return
+	}
+	if result.RowsAffected() == 0 {
+		return
+	}
+
+	log.Printf("Catching up %d missed runs of job %s for tenant %s", len(missedRunTimes), jobName, tenantID)
+	for _, scheduledTime := range missedRunTimes {
+		if jm.context.Err() != nil {
+			return
+		}
+		jm.executeJobWithLock(job, jobExecution{
+			userID:        systemUserID,
+			scheduledTime: scheduledTime,
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// maxOneOffJobsPerClaim bounds the one-off jobs an instance claims at once, leaving the others
+// to the other instances
+const maxOneOffJobsPerClaim = 100
+
+// ScheduleOnce schedules a single run of a job of the given type for a tenant at runAt, e.g. to send
+// a reminder. The run is stored in cron_one_off_jobs, so it survives restarts and is claimed by any
+// instance that registered the job type with RegisterJobType. The payload, encoded as JSON, is passed
+// to the factory of the job type and delivered to the job like the payload of a registered job.
+// It returns the ID of the one-off job, which is also the request ID of its audit log.
+func (jm *JobManager) ScheduleOnce(ctx context.Context, jobType string, tenantID string, runAt time.Time, payload interface{}) (uuid.UUID, error) {
+	var encoded json.RawMessage
+	if payload != nil {
+		var err error
+		encoded, err = json.Marshal(payload)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("failed to encode payload of one-off job %s: %w", jobType, err)
+		}
+	}
+
+	oneOffJob, err := jm.store.CreateOneOffJob(ctx, repository.CreateOneOffJobParams{
+		JobType:  jobType,
+		RunAt:    runAt,
+		Payload:  encoded,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to schedule one-off job %s: %w", jobType, err)
+	}
+
+	log.Printf("One-off job %s (%s) scheduled for tenant %s at %s", jobType, oneOffJob.ID, tenantID, runAt)
+	return oneOffJob.ID, nil
+}
+
+// startOneOffRoutine periodically claims and runs the due one-off jobs
+func (jm *JobManager) startOneOffRoutine() {
+	jm.oneOffTicker = time.NewTicker(jm.options.oneOffInterval)
+	stop := jm.stopRoutines
+
+	go func() {
+		// Run the one-off jobs that became due while no instance was running
+		jm.runDueOneOffJobs()
+
+		for {
+			select {
+			case <-jm.oneOffTicker.C:
+				jm.runDueOneOffJobs()
+			case <-stop:
+				return
+			case <-jm.context.Done():
+				return
+			}
+		}
+	}()
+
+	log.Printf("One-off routine started")
+}
+
+// runDueOneOffJobs claims the due one-off jobs of the registered job types and runs them
+func (jm *JobManager) runDueOneOffJobs() {
+	jm.mutex.Lock()
+	jobTypes := jm.jobTypeNames()
+	jm.mutex.Unlock()
+
+	if len(jobTypes) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// A claim older than the stale lock delay belongs to an instance that stopped during the run
+	oneOffJobs, err := jm.store.ClaimDueOneOffJobs(ctx, repository.ClaimDueOneOffJobsParams{
+		InstanceID:    jm.instanceID,
+		JobTypes:      jobTypes,
+		ClaimedBefore: time.Now().Add(-jm.options.staleLockAfter),
+		Limit:         maxOneOffJobsPerClaim,
+	})
+	if err != nil {
+		log.Printf("Error claiming due one-off jobs: %v", err)
+		return
+	}
+
+	for _, oneOffJob := range oneOffJobs {
+		go jm.runOneOffJob(oneOffJob)
+	}
+}
+
+// oneOffJobStore is the part of the store completing and releasing claimed one-off jobs
+type oneOffJobStore interface {
+	CompleteOneOffJob(ctx context.Context, arg repository.CompleteOneOffJobParams) error
+	ReleaseOneOffJob(ctx context.Context, arg repository.ReleaseOneOffJobParams) error
+	CreateJobAuditLog(ctx context.Context, arg repository.CreateJobAuditLogParams) (repository.CronJobAuditLog, error)
+}
+
+// runOneOffJob runs a claimed one-off job once, keeping its claim fresh meanwhile
+func (jm *JobManager) runOneOffJob(oneOffJob repository.CronOneOffJob) {
+	// Keep the claim from going stale, and being taken over by another instance, while the job runs
+	heartbeatStop := make(chan struct{})
+	defer close(heartbeatStop)
+	go jm.startOneOffHeartbeat(oneOffJob.ID, heartbeatStop)
+
+	jm.runClaimedOneOffJob(jm.store, oneOffJob, jm.executeJobWithLock)
+}
+
+// runClaimedOneOffJob builds a claimed one-off job with the factory of its type and runs it with execute,
+// executeJobWithLock outside of tests. The one-off job is completed once it ran, whatever the outcome of
+// the run, which is in its audit log, or when it cannot be built. When the run did not happen, e.g.
+// because the lock of the job was held or its type is no longer registered, its claim is released so
+// it is claimed again.
+func (jm *JobManager) runClaimedOneOffJob(store oneOffJobStore, oneOffJob repository.CronOneOffJob, execute func(Job, jobExecution) string) {
+	spec := JobSpec{
+		Name:     oneOffJobName(oneOffJob),
+		TenantID: oneOffJob.TenantID,
+		Payload:  oneOffJob.Payload,
+	}
+
+	factory := jm.jobFactory(oneOffJob.JobType)
+	if factory == nil {
+		// Only claimed for registered job types, unless the factory was removed meanwhile.
+		// Leave it to the instances that still know the job type.
+		log.Printf("Job type %s of one-off job %s no longer registered, releasing it", oneOffJob.JobType, spec.Name)
+		jm.releaseOneOffJob(store, oneOffJob.ID)
+		return
+	}
+	job, err := factory(spec)
+	if err != nil {
+		log.Printf("Error building one-off job %s for tenant %s: %v", spec.Name, oneOffJob.TenantID, err)
+		jm.recordFailedOneOffJob(store, oneOffJob, spec.Name, fmt.Sprintf("Failed to build job: %v", err))
+		jm.completeOneOffJob(store, oneOffJob.ID)
+		return
+	}
+
+	log.Printf("Running one-off job %s for tenant %s scheduled at %s", spec.Name, oneOffJob.TenantID, oneOffJob.RunAt)
+	outcome := execute(job, jobExecution{
+		requestID:     oneOffJob.ID.String(),
+		userID:        systemUserID,
+		scheduledTime: oneOffJob.RunAt,
+		payload:       oneOffJob.Payload,
+		oneOffJobID:   oneOffJob.ID,
+	})
+	if outcome == "skipped" || outcome == "deferred" {
+		// Deferred runs already moved the one-off job to the end of the suppression
+		jm.releaseOneOffJob(store, oneOffJob.ID)
+		return
+	}
+	jm.completeOneOffJob(store, oneOffJob.ID)
+}
+
+// startOneOffHeartbeat refreshes the claim of a one-off job until stopChan is closed
+func (jm *JobManager) startOneOffHeartbeat(id uuid.UUID, stopChan <-chan struct{}) {
+	ticker := time.NewTicker(jm.options.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			err := jm.store.HeartbeatOneOffJob(ctx, repository.HeartbeatOneOffJobParams{
+				ID:         id,
+				InstanceID: jm.instanceID,
+			})
+			cancel()
+			if err != nil {
+				log.Printf("Error updating heartbeat of one-off job %s: %v", id, err)
+			}
+		case <-stopChan:
+			return
+		case <-jm.context.Done():
+			return
+		}
+	}
+}
+
+// oneOffJobName returns the name of the job built for a one-off job, unique per one-off job
+func oneOffJobName(oneOffJob repository.CronOneOffJob) string {
+	return oneOffJob.JobType + "/" + oneOffJob.ID.String()
+}
+
+// completeOneOffJob marks a one-off job as run, so it is not claimed again
+func (jm *JobManager) completeOneOffJob(store oneOffJobStore, id uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := store.CompleteOneOffJob(ctx, repository.CompleteOneOffJobParams{
+		ID:         id,
+		InstanceID: jm.instanceID,
+	})
+	if err != nil {
+		log.Printf("Error completing one-off job %s: %v", id, err)
+	}
+}
+
+// releaseOneOffJob releases the claim of a one-off job whose run did not happen, so it is claimed again
+func (jm *JobManager) releaseOneOffJob(store oneOffJobStore, id uuid.UUID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := store.ReleaseOneOffJob(ctx, repository.ReleaseOneOffJobParams{
+		ID:         id,
+		InstanceID: jm.instanceID,
+	})
+	if err != nil {
+		log.Printf("Error releasing one-off job %s: %v", id, err)
+	}
+}
+
+// recordFailedOneOffJob records a failed audit log for a one-off job that could not be built
+func (jm *JobManager) recordFailedOneOffJob(store oneOffJobStore, oneOffJob repository.CronOneOffJob, jobName string, errorMsg string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	_, err := store.CreateJobAuditLog(ctx, repository.CreateJobAuditLogParams{
+		UserID:        systemUserID,
+		AppID:         jm.instanceID,
+		RequestID:     oneOffJob.ID.String(),
+		JobName:       jobName,
+		ScheduledTime: pgtype.Timestamp{Time: oneOffJob.RunAt.In(now.Location()), Valid: true},
+		StartTime:     pgtype.Timestamp{Time: now, Valid: true},
+		EndTime:       pgtype.Timestamp{Time: now, Valid: true},
+		Status:        "failed",
+		Error:         pgtype.Text{String: errorMsg, Valid: true},
+		Attempt:       1,
+		TenantID:      oneOffJob.TenantID,
+	})
+	if err != nil {
+		log.Printf("Error creating audit log for one-off job %s (tenant %s): %v", jobName, oneOffJob.TenantID, err)
+	}
+}
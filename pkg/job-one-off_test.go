@@ -0,0 +1,146 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// testOneOffStore records the one-off jobs completed and released, and the audit logs created
+type testOneOffStore struct {
+	completed []uuid.UUID
+	released  []uuid.UUID
+	auditLogs []repository.CreateJobAuditLogParams
+}
+
+func (s *testOneOffStore) CompleteOneOffJob(ctx context.Context, arg repository.CompleteOneOffJobParams) error {
+	s.completed = append(s.completed, arg.ID)
+	return nil
+}
+
+func (s *testOneOffStore) ReleaseOneOffJob(ctx context.Context, arg repository.ReleaseOneOffJobParams) error {
+	s.released = append(s.released, arg.ID)
+	return nil
+}
+
+func (s *testOneOffStore) CreateJobAuditLog(ctx context.Context, arg repository.CreateJobAuditLogParams) (repository.CronJobAuditLog, error) {
+	s.auditLogs = append(s.auditLogs, arg)
+	return repository.CronJobAuditLog{ID: uuid.New()}, nil
+}
+
+func TestRunClaimedOneOffJob(t *testing.T) {
+	oneOffJob := repository.CronOneOffJob{
+		ID:       uuid.New(),
+		JobType:  "reminder",
+		TenantID: "tenant",
+		RunAt:    time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC),
+		Payload:  json.RawMessage(`{"user": "ada"}`),
+	}
+	building := func(spec JobSpec) (Job, error) {
+		return &factoryTestJob{name: spec.Name, tenantID: spec.TenantID}, nil
+	}
+	failing := func(spec JobSpec) (Job, error) {
+		return nil, errors.New("invalid payload")
+	}
+
+	tests := []struct {
+		name            string
+		factory         JobFactory
+		outcome         string
+		expectRun       bool
+		expectCompleted bool
+		expectReleased  bool
+		expectAuditLog  bool
+	}{
+		{
+			name:            "Run completed",
+			factory:         building,
+			outcome:         "completed",
+			expectRun:       true,
+			expectCompleted: true,
+		},
+		{
+			name:            "Run failed",
+			factory:         building,
+			outcome:         "failed",
+			expectRun:       true,
+			expectCompleted: true,
+		},
+		{
+			name:           "Run skipped",
+			factory:        building,
+			outcome:        "skipped",
+			expectRun:      true,
+			expectReleased: true,
+		},
+		{
+			name:           "Run deferred",
+			factory:        building,
+			outcome:        "deferred",
+			expectRun:      true,
+			expectReleased: true,
+		},
+		{
+			name:            "Factory error",
+			factory:         failing,
+			expectCompleted: true,
+			expectAuditLog:  true,
+		},
+		{
+			name:           "Job type no longer registered",
+			expectReleased: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jm := &JobManager{instanceID: "instance", jobTypes: map[string]JobFactory{}}
+			if tt.factory != nil {
+				jm.jobTypes[oneOffJob.JobType] = tt.factory
+			}
+			store := &testOneOffStore{}
+
+			var runs []jobExecution
+			jm.runClaimedOneOffJob(store, oneOffJob, func(job Job, execution jobExecution) string {
+				if job.Name() != oneOffJobName(oneOffJob) {
+					t.Errorf("job name = %s, want %s", job.Name(), oneOffJobName(oneOffJob))
+				}
+				runs = append(runs, execution)
+				return tt.outcome
+			})
+
+			if ran := len(runs) > 0; ran != tt.expectRun {
+				t.Fatalf("ran = %v, want %v", ran, tt.expectRun)
+			}
+			if tt.expectRun {
+				execution := runs[0]
+				if execution.oneOffJobID != oneOffJob.ID || execution.userID != systemUserID ||
+					!execution.scheduledTime.Equal(oneOffJob.RunAt) || string(execution.payload) != string(oneOffJob.Payload) {
+					t.Errorf("execution = %+v, want the one-off job, its run time and payload", execution)
+				}
+			}
+			if completed := slices.Contains(store.completed, oneOffJob.ID); completed != tt.expectCompleted {
+				t.Errorf("completed = %v, want %v", completed, tt.expectCompleted)
+			}
+			if released := slices.Contains(store.released, oneOffJob.ID); released != tt.expectReleased {
+				t.Errorf("released = %v, want %v", released, tt.expectReleased)
+			}
+			if (len(store.auditLogs) > 0) != tt.expectAuditLog {
+				t.Fatalf("audit logs = %v, expectAuditLog %v", store.auditLogs, tt.expectAuditLog)
+			}
+			if tt.expectAuditLog {
+				auditLog := store.auditLogs[0]
+				if auditLog.Status != "failed" || auditLog.RequestID != oneOffJob.ID.String() || auditLog.TenantID != oneOffJob.TenantID {
+					t.Errorf("audit log = %+v, want a failed audit log of the one-off job", auditLog)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package cron
+
+import "time"
+
+// JobManagerOption configures a JobManager
+type JobManagerOption func(*jobManagerOptions)
+
+// jobManagerOptions holds the settings of a JobManager
+type jobManagerOptions struct {
+	lockNamespace string // Namespace of the advisory lock IDs
+	legacyLockIDs bool   // Also take the legacy 32-bit advisory locks
+
+	lockTimeout       time.Duration // Timeout to acquire the advisory and database locks of a job
+	lockCheckInterval time.Duration // Interval of the checks of the connection holding an advisory lock
+	shutdownTimeout   time.Duration // How long StopScheduler waits for running jobs
+	heartbeatInterval time.Duration // Interval of the heartbeat of long-running jobs
+	cleanupInterval   time.Duration // Interval of the stale jobs cleanup routine
+	syncInterval      time.Duration // Interval of the registrations sync routine
+	staleLockAfter    time.Duration // Age after which a running job lock can be taken over by another run
+	staleCleanupAfter time.Duration // Age after which a running job is marked as failed by the cleanup
+	retention         time.Duration // How long completed and failed jobs are kept in cron_jobs
+	registrationTTL   time.Duration // How long a registration is kept when no instance refreshes it
+	runRequestExpiry  time.Duration // Age after which an unclaimed run request is ignored
+	oneOffInterval    time.Duration // Interval of the checks for due one-off jobs
+
+	middlewares []Middleware // Middlewares wrapping the run of every job
+
+	httpAllowedHosts []string // Hosts the HTTP jobs built by HTTPJobFactory may call
+}
+
+// defaultJobManagerOptions returns the settings used when no option is given
+func defaultJobManagerOptions() jobManagerOptions {
+	return jobManagerOptions{
+		lockNamespace:     DefaultLockNamespace,
+		legacyLockIDs:     true,
+		lockTimeout:       60 * time.Second,
+		lockCheckInterval: 30 * time.Second,
+		shutdownTimeout:   30 * time.Second,
+		heartbeatInterval: 2 * time.Minute,
+		cleanupInterval:   5 * time.Minute,
+		syncInterval:      30 * time.Second,
+		staleLockAfter:    10 * time.Minute,
+		staleCleanupAfter: 15 * time.Minute,
+		retention:         7 * 24 * time.Hour,
+		registrationTTL:   24 * time.Hour,
+		runRequestExpiry:  5 * time.Minute,
+		oneOffInterval:    10 * time.Second,
+	}
+}
+
+// newJobManagerOptions applies the options over the defaults. Non-positive durations are ignored.
+func newJobManagerOptions(opts ...JobManagerOption) jobManagerOptions {
+	options := defaultJobManagerOptions()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+	return options
+}
+
+// setDuration sets target to d when d is positive
+func setDuration(target *time.Duration, d time.Duration) {
+	if d > 0 {
+		*target = d
+	}
+}
+
+// WithLockNamespace sets the namespace hashed into the advisory lock IDs (default DefaultLockNamespace).
+// All the instances sharing jobs must use the same namespace.
+func WithLockNamespace(namespace string) JobManagerOption {
+	return func(o *jobManagerOptions) {
+		if namespace != "" {
+			o.lockNamespace = namespace
+		}
+	}
+}
+
+// WithLegacyLockIDs sets whether the legacy 32-bit advisory locks are taken along with the 64-bit ones
+// (default true), so that instances running an older version never run a job at the same time.
+// Disable it once every instance uses 64-bit lock IDs.
+func WithLegacyLockIDs(enabled bool) JobManagerOption {
+	return func(o *jobManagerOptions) { o.legacyLockIDs = enabled }
+}
+
+// WithLockTimeout sets the timeout to acquire the locks of a job (default 60s)
+func WithLockTimeout(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.lockTimeout, d) }
+}
+
+// WithLockCheckInterval sets how often the connection holding the advisory lock of a running job
+// is checked (default 30s). The job is canceled when the connection, and so the lock, is lost.
+func WithLockCheckInterval(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.lockCheckInterval, d) }
+}
+
+// WithShutdownTimeout sets how long StopScheduler waits for running jobs (default 30s)
+func WithShutdownTimeout(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.shutdownTimeout, d) }
+}
+
+// WithHeartbeatInterval sets the heartbeat interval of long-running jobs (default 2m).
+// It should stay well below the stale lock delay.
+func WithHeartbeatInterval(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.heartbeatInterval, d) }
+}
+
+// WithCleanupInterval sets the interval of the stale jobs cleanup (default 5m)
+func WithCleanupInterval(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.cleanupInterval, d) }
+}
+
+// WithSyncInterval sets how often schedule overrides and missed run requests are loaded (default 30s)
+func WithSyncInterval(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.syncInterval, d) }
+}
+
+// WithStaleLockAfter sets the age after which the lock of a running job without
+// heartbeat is considered stale and can be taken over by another run (default 10m)
+func WithStaleLockAfter(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.staleLockAfter, d) }
+}
+
+// WithStaleCleanupAfter sets the age after which a running job without heartbeat
+// is marked as failed by the cleanup routine (default 15m)
+func WithStaleCleanupAfter(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.staleCleanupAfter, d) }
+}
+
+// WithRetention sets how long completed and failed jobs are kept in cron_jobs (default 7 days)
+func WithRetention(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.retention, d) }
+}
+
+// WithRegistrationTTL sets how long a registered job is kept when no instance refreshes it (default 24h)
+func WithRegistrationTTL(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.registrationTTL, d) }
+}
+
+// WithRunRequestExpiry sets the age after which an unclaimed "run now" request is ignored (default 5m)
+func WithRunRequestExpiry(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.runRequestExpiry, d) }
+}
+
+// WithOneOffInterval sets how often due one-off jobs are claimed (default 10s), which bounds
+// how late they start
+func WithOneOffInterval(d time.Duration) JobManagerOption {
+	return func(o *jobManagerOptions) { setDuration(&o.oneOffInterval, d) }
+}
+
+// WithMiddleware adds middlewares wrapping each attempt of every job, the first one being the outermost.
+// They run around the middlewares of the jobs implementing MiddlewareJob.
+func WithMiddleware(middlewares ...Middleware) JobManagerOption {
+	return func(o *jobManagerOptions) { o.middlewares = append(o.middlewares, middlewares...) }
+}
+
+// WithHTTPAllowedHosts sets the hosts the HTTP jobs built by HTTPJobFactory, e.g. defined by tenants
+// through the API, may call and be redirected to (default none). A host starting with "*." allows
+// its subdomains, e.g. "*.example.com". Jobs built with NewHTTPJob are not restricted.
+func WithHTTPAllowedHosts(hosts ...string) JobManagerOption {
+	return func(o *jobManagerOptions) { o.httpAllowedHosts = append(o.httpAllowedHosts, hosts...) }
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ConfigurableJob is implemented by jobs configured by a payload, e.g. thresholds tuned per tenant.
+// The payload is the one set on the registration of the job through the API, or the one of a
+// one-off job.
+type ConfigurableJob interface {
+	Job
+
+	// Configure is called with the current payload of the job before each run, nil when it has
+	// none. An error fails the run without calling Run.
+	Configure(payload json.RawMessage) error
+}
+
+// payloadContextKey is the context key of the payload of a run
+type payloadContextKey struct{}
+
+// PayloadFromContext returns the payload of the job run by ctx, nil when it has none.
+// It is an alternative to ConfigurableJob for jobs reading their payload in Run.
+func PayloadFromContext(ctx context.Context) json.RawMessage {
+	payload, _ := ctx.Value(payloadContextKey{}).(json.RawMessage)
+	return payload
+}
+
+// withPayload returns a context carrying the payload of a run
+func withPayload(ctx context.Context, payload json.RawMessage) context.Context {
+	if len(payload) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, payloadContextKey{}, payload)
+}
+
+// configureJob delivers the payload to the job when it implements ConfigurableJob
+func configureJob(job Job, payload json.RawMessage) error {
+	configurableJob, ok := job.(ConfigurableJob)
+	if !ok {
+		return nil
+	}
+	if err := configurableJob.Configure(payload); err != nil {
+		return fmt.Errorf("failed to configure job: %w", err)
+	}
+	return nil
+}
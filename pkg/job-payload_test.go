@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// configurableTestJob records the payloads it is configured with
+type configurableTestJob struct {
+	Job
+	payloads []json.RawMessage
+	err      error
+}
+
+func (j *configurableTestJob) Configure(payload json.RawMessage) error {
+	j.payloads = append(j.payloads, payload)
+	return j.err
+}
+
+func TestConfigureJob(t *testing.T) {
+	errInvalid := errors.New("threshold must be positive")
+
+	tests := []struct {
+		name          string
+		job           Job
+		payload       json.RawMessage
+		expectPayload bool
+		expectError   error
+	}{
+		{
+			name:    "Job not configurable",
+			job:     &factoryTestJob{name: "report", tenantID: "tenant"},
+			payload: json.RawMessage(`{"threshold": 10}`),
+		},
+		{
+			name:          "Payload delivered",
+			job:           &configurableTestJob{},
+			payload:       json.RawMessage(`{"threshold": 10}`),
+			expectPayload: true,
+		},
+		{
+			name:          "No payload",
+			job:           &configurableTestJob{},
+			expectPayload: true,
+		},
+		{
+			name:          "Payload rejected",
+			job:           &configurableTestJob{err: errInvalid},
+			payload:       json.RawMessage(`{"threshold": -1}`),
+			expectPayload: true,
+			expectError:   errInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := configureJob(tt.job, tt.payload)
+			if !errors.Is(err, tt.expectError) {
+				t.Fatalf("configureJob() error = %v, want %v", err, tt.expectError)
+			}
+			configurable, ok := tt.job.(*configurableTestJob)
+			if !ok {
+				return
+			}
+			if len(configurable.payloads) != 1 || string(configurable.payloads[0]) != string(tt.payload) {
+				t.Errorf("Configure() called with %q, want %q", configurable.payloads, tt.payload)
+			}
+		})
+	}
+}
+
+func TestPayloadFromContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       json.RawMessage
+		expectPayload json.RawMessage
+	}{
+		{
+			name:          "Payload",
+			payload:       json.RawMessage(`{"threshold": 10}`),
+			expectPayload: json.RawMessage(`{"threshold": 10}`),
+		},
+		{
+			name: "No payload",
+		},
+		{
+			name:    "Empty payload",
+			payload: json.RawMessage{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := PayloadFromContext(withPayload(context.Background(), tt.payload))
+			if string(payload) != string(tt.expectPayload) || (payload == nil) != (tt.expectPayload == nil) {
+				t.Errorf("PayloadFromContext() = %q, want %q", payload, tt.expectPayload)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy defines how a failed run is retried before the next scheduled run
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first run. 1 or less disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// Multiplier is applied to the delay after each retry, 2 when not set
+	Multiplier float64
+
+	// MaxBackoff caps the delay between attempts, no cap when zero
+	MaxBackoff time.Duration
+
+	// Jitter randomly changes each delay by up to this fraction of it, between 0 and 1
+	Jitter float64
+
+	// Retryable tells whether an error is worth retrying, all errors are retried when nil
+	Retryable func(err error) bool
+}
+
+// RetryableJob is implemented by jobs whose failed runs are retried.
+// Retries hold the job locks, so no other run of the job starts in the meantime.
+type RetryableJob interface {
+	Job
+
+	// RetryPolicy returns how failed runs of the job are retried
+	RetryPolicy() RetryPolicy
+}
+
+// retryPolicyOf returns the retry policy of the job, or a policy without retries
+func retryPolicyOf(job Job) RetryPolicy {
+	if retryableJob, ok := job.(RetryableJob); ok {
+		return retryableJob.RetryPolicy()
+	}
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// retries reports whether the policy allows any retry
+func (p RetryPolicy) retries() bool {
+	return p.MaxAttempts > 1
+}
+
+// shouldRetry reports whether the given attempt, which failed with err, is retried
+func (p RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// backoff returns the delay before the retry following the given attempt
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	// Without cap the delay grows past what a time.Duration holds, whose conversion would overflow
+	maxDelay := float64(math.MaxInt64)
+	delay = math.Min(delay, maxDelay)
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which does not convert either
+	if delay >= maxDelay {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
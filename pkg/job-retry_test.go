@@ -0,0 +1,149 @@
+package cron
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+	onlyTemporary := func(err error) bool { return errors.Is(err, errTemporary) }
+
+	tests := []struct {
+		name        string
+		policy      RetryPolicy
+		attempt     int
+		err         error
+		expectRetry bool
+	}{
+		{
+			name:    "No retry policy",
+			policy:  RetryPolicy{},
+			attempt: 1,
+			err:     errTemporary,
+		},
+		{
+			name:        "Attempt below the limit",
+			policy:      RetryPolicy{MaxAttempts: 3},
+			attempt:     2,
+			err:         errTemporary,
+			expectRetry: true,
+		},
+		{
+			name:    "Last attempt",
+			policy:  RetryPolicy{MaxAttempts: 3},
+			attempt: 3,
+			err:     errTemporary,
+		},
+		{
+			name:        "Retryable error",
+			policy:      RetryPolicy{MaxAttempts: 3, Retryable: onlyTemporary},
+			attempt:     1,
+			err:         errTemporary,
+			expectRetry: true,
+		},
+		{
+			name:    "Error not retryable",
+			policy:  RetryPolicy{MaxAttempts: 3, Retryable: onlyTemporary},
+			attempt: 1,
+			err:     errPermanent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if retry := tt.policy.shouldRetry(tt.attempt, tt.err); retry != tt.expectRetry {
+				t.Errorf("shouldRetry(%d, %v) = %v, want %v", tt.attempt, tt.err, retry, tt.expectRetry)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RetryPolicy
+		attempt   int
+		expectMin time.Duration
+		expectMax time.Duration
+	}{
+		{
+			name:      "First retry",
+			policy:    RetryPolicy{InitialBackoff: time.Second},
+			attempt:   1,
+			expectMin: time.Second,
+			expectMax: time.Second,
+		},
+		{
+			name:      "Default multiplier",
+			policy:    RetryPolicy{InitialBackoff: time.Second},
+			attempt:   3,
+			expectMin: 4 * time.Second,
+			expectMax: 4 * time.Second,
+		},
+		{
+			name:      "Custom multiplier",
+			policy:    RetryPolicy{InitialBackoff: time.Second, Multiplier: 3},
+			attempt:   3,
+			expectMin: 9 * time.Second,
+			expectMax: 9 * time.Second,
+		},
+		{
+			name:      "Capped delay",
+			policy:    RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second},
+			attempt:   10,
+			expectMin: 5 * time.Second,
+			expectMax: 5 * time.Second,
+		},
+		{
+			name:      "Jitter",
+			policy:    RetryPolicy{InitialBackoff: 10 * time.Second, Jitter: 0.2},
+			attempt:   1,
+			expectMin: 8 * time.Second,
+			expectMax: 12 * time.Second,
+		},
+		{
+			name:      "Jitter above 1 is capped",
+			policy:    RetryPolicy{InitialBackoff: 10 * time.Second, Jitter: 5},
+			attempt:   1,
+			expectMin: 0,
+			expectMax: 20 * time.Second,
+		},
+		{
+			name:      "Delay without cap beyond the range of a duration",
+			policy:    RetryPolicy{InitialBackoff: time.Second},
+			attempt:   100,
+			expectMin: time.Duration(math.MaxInt64),
+			expectMax: time.Duration(math.MaxInt64),
+		},
+		{
+			name:      "Jitter on a delay beyond the range of a duration",
+			policy:    RetryPolicy{InitialBackoff: time.Second, Jitter: 0.5},
+			attempt:   100,
+			expectMin: time.Duration(math.MaxInt64 / 2),
+			expectMax: time.Duration(math.MaxInt64),
+		},
+		{
+			name:      "Jitter applied after the cap",
+			policy:    RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second, Jitter: 0.5},
+			attempt:   10,
+			expectMin: 5 * time.Second,
+			expectMax: 15 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Jitter is random, so check the bounds over many draws
+			for i := 0; i < 1000; i++ {
+				delay := tt.policy.backoff(tt.attempt)
+				if delay < tt.expectMin || delay > tt.expectMax {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, delay, tt.expectMin, tt.expectMax)
+				}
+			}
+		})
+	}
+}
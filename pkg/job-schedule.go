@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/cto-up/cron-lib/pkg/utils"
+)
+
+// locationOf returns the time zone of the job schedule, or nil for the time zone of the server
+func locationOf(job Job) *time.Location {
+	if locatedJob, ok := job.(LocatedJob); ok {
+		return locatedJob.Location()
+	}
+	return nil
+}
+
+// parseJobSchedule parses a schedule of the job, evaluated in the time zone of the job.
+// H tokens are spread with the tenant and the name of the job.
+func parseJobSchedule(job Job, spec string) (cron.Schedule, error) {
+	resolved, err := utils.ResolveHashedSchedule(spec, utils.ScheduleSeed(job.TenantID(), job.Name()))
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := utils.ParseSchedule(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return utils.InLocation(schedule, locationOf(job)), nil
+}
+
+// scheduledRun is the cron entry of a job. It tracks the fire time each run belongs to,
+// as robfig/cron does not pass it to the job.
+type scheduledRun struct {
+	jm       *JobManager
+	job      Job
+	schedule cron.Schedule
+	mutex    sync.Mutex
+	next     time.Time // Next expected fire time
+}
+
+// newScheduledRun creates the cron entry of a job scheduled at now
+func newScheduledRun(jm *JobManager, job Job, schedule cron.Schedule, now time.Time) *scheduledRun {
+	return &scheduledRun{
+		jm:       jm,
+		job:      job,
+		schedule: schedule,
+		next:     schedule.Next(now),
+	}
+}
+
+// Run implements cron.Job
+func (r *scheduledRun) Run() {
+	r.jm.executeJobWithLock(r.job, jobExecution{
+		userID:        systemUserID,
+		scheduledTime: r.fireTime(time.Now()),
+	})
+}
+
+// fireTime returns the fire time of a run started at now: the latest fire time not after now.
+// Runs started late under load keep the fire time they belong to.
+func (r *scheduledRun) fireTime(now time.Time) time.Time {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	fireTime := r.next
+	if fireTime.IsZero() || fireTime.After(now) {
+		// Woken up before the expected fire time, e.g. after a clock change
+		return now
+	}
+	for next := r.schedule.Next(fireTime); !next.IsZero() && !next.After(now); next = r.schedule.Next(next) {
+		fireTime = next
+	}
+	r.next = r.schedule.Next(fireTime)
+	return fireTime
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cto-up/cron-lib/pkg/utils"
+)
+
+func TestScheduledRunFireTime(t *testing.T) {
+	// Every 5 minutes
+	schedule, err := utils.ParseSchedule("0 */5 * * * *")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	at := func(hour, min, sec int) time.Time {
+		return time.Date(2025, 3, 10, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name           string
+		next           time.Time   // Next expected fire time before the runs
+		starts         []time.Time // Start times of the successive runs
+		expectFireTime []time.Time
+		expectNext     time.Time // Next expected fire time after the runs
+	}{
+		{
+			name:           "Run on time",
+			next:           at(10, 5, 0),
+			starts:         []time.Time{at(10, 5, 0)},
+			expectFireTime: []time.Time{at(10, 5, 0)},
+			expectNext:     at(10, 10, 0),
+		},
+		{
+			name:           "Run started late keeps its fire time",
+			next:           at(10, 5, 0),
+			starts:         []time.Time{at(10, 7, 30)},
+			expectFireTime: []time.Time{at(10, 5, 0)},
+			expectNext:     at(10, 10, 0),
+		},
+		{
+			name:           "Run started after several fire times belongs to the latest",
+			next:           at(10, 5, 0),
+			starts:         []time.Time{at(10, 17, 0)},
+			expectFireTime: []time.Time{at(10, 15, 0)},
+			expectNext:     at(10, 20, 0),
+		},
+		{
+			name:           "Successive runs",
+			next:           at(10, 5, 0),
+			starts:         []time.Time{at(10, 5, 1), at(10, 10, 2), at(10, 15, 0)},
+			expectFireTime: []time.Time{at(10, 5, 0), at(10, 10, 0), at(10, 15, 0)},
+			expectNext:     at(10, 20, 0),
+		},
+		{
+			name:           "Run started before the expected fire time",
+			next:           at(10, 5, 0),
+			starts:         []time.Time{at(10, 4, 59)},
+			expectFireTime: []time.Time{at(10, 4, 59)},
+			expectNext:     at(10, 5, 0),
+		},
+		{
+			name:           "No expected fire time",
+			starts:         []time.Time{at(10, 7, 0)},
+			expectFireTime: []time.Time{at(10, 7, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := &scheduledRun{schedule: schedule, next: tt.next}
+			for i, start := range tt.starts {
+				if fireTime := run.fireTime(start); !fireTime.Equal(tt.expectFireTime[i]) {
+					t.Errorf("fireTime(%s) = %s, want %s", start, fireTime, tt.expectFireTime[i])
+				}
+			}
+			if !run.next.Equal(tt.expectNext) {
+				t.Errorf("next = %s, want %s", run.next, tt.expectNext)
+			}
+		})
+	}
+}
+
+// locatedTestJob is a job scheduled in a time zone, whose own next run time ignores it
+type locatedTestJob struct {
+	Job
+	schedule string
+	location *time.Location
+}
+
+func (j *locatedTestJob) Name() string             { return "report" }
+func (j *locatedTestJob) TenantID() string         { return "tenant" }
+func (j *locatedTestJob) Schedule() string         { return j.schedule }
+func (j *locatedTestJob) NextRunTime() time.Time   { return time.Time{} }
+func (j *locatedTestJob) Location() *time.Location { return j.location }
+
+func TestNextRunTimeOfLocatedJob(t *testing.T) {
+	tokyo := time.FixedZone("Asia/Tokyo", 9*60*60)
+	job := &locatedTestJob{schedule: "0 0 8 * * *", location: tokyo}
+	jm := &JobManager{overrides: map[string]string{}}
+
+	next := jm.nextRunTime(job)
+	if next.IsZero() {
+		t.Fatalf("nextRunTime() = zero time, want the next 08:00 in Tokyo")
+	}
+	if local := next.In(tokyo); local.Hour() != 8 || local.Minute() != 0 {
+		t.Errorf("nextRunTime() = %s, want 08:00 in Tokyo", local)
+	}
+	if !next.After(time.Now()) || next.After(time.Now().Add(24*time.Hour)) {
+		t.Errorf("nextRunTime() = %s, want within the next 24 hours", next)
+	}
+}
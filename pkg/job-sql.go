@@ -0,0 +1,246 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/cto-up/cron-lib/pkg/utils"
+)
+
+// SQLJobType is the job type of SQL jobs, registered with
+// jm.RegisterJobType(cron.SQLJobType, jm.SQLJobFactory)
+const SQLJobType = "sql"
+
+// SQLStatement is a statement SQL jobs may run, registered with RegisterSQLStatement.
+// SQL jobs only refer to statements by name, so tenants defining jobs through the API cannot run
+// arbitrary SQL.
+type SQLStatement struct {
+	// SQL is the statement or function call. Unless Global is set, it receives the tenant ID of the
+	// job as $1 and must only touch the rows of that tenant,
+	// e.g. "DELETE FROM sessions WHERE tenant_id = $1 AND expires_at < now()".
+	SQL string
+
+	// Global statements take no parameter, e.g. "REFRESH MATERIALIZED VIEW CONCURRENTLY daily_stats".
+	// As they touch the rows of all tenants, they require Tenants.
+	Global bool
+
+	// Tenants are the tenants allowed to run the statement, all tenants when empty.
+	// Required for global statements, e.g. the tenant of the platform operators.
+	Tenants []string
+}
+
+// SQLJobConfig describes the statement run by an SQL job.
+// It is also the payload of SQL jobs defined through the API or scheduled once.
+type SQLJobConfig struct {
+	// Statement is the name of the statement, registered with RegisterSQLStatement
+	Statement string `json:"statement"`
+
+	// Transaction runs the statement inside a transaction, rolled back when it fails
+	Transaction bool `json:"transaction,omitempty"`
+
+	// StatementTimeoutSeconds sets the statement_timeout of the statement, none when zero
+	StatementTimeoutSeconds int `json:"statement_timeout_seconds,omitempty"`
+}
+
+// SQLJob is a job running an allowed SQL statement on the database of the job manager on its schedule.
+// The number of rows affected is recorded in the audit log.
+type SQLJob struct {
+	name      string
+	tenantID  string
+	schedule  string
+	config    SQLJobConfig
+	statement SQLStatement
+	pool      *pgxpool.Pool
+}
+
+// RegisterSQLStatement allows SQL jobs to run a statement under the given name
+func (jm *JobManager) RegisterSQLStatement(name string, statement SQLStatement) error {
+	if strings.TrimSpace(statement.SQL) == "" {
+		return fmt.Errorf("SQL statement %s is empty", name)
+	}
+	if statement.Global && len(statement.Tenants) == 0 {
+		return fmt.Errorf("global SQL statement %s must list the tenants allowed to run it", name)
+	}
+
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	if _, exists := jm.sqlStatements[name]; exists {
+		log.Printf("SQL statement %s already registered, replacing it", name)
+	}
+	jm.sqlStatements[name] = statement
+	return nil
+}
+
+// sqlStatement returns the statement registered under the given name
+func (jm *JobManager) sqlStatement(name string) (SQLStatement, bool) {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	statement, exists := jm.sqlStatements[name]
+	return statement, exists
+}
+
+// NewSQLJob returns an SQL job running the statement described by config, to register with RegisterJob.
+// The statement must be registered and allowed for the tenant.
+func (jm *JobManager) NewSQLJob(name string, tenantID string, schedule string, config SQLJobConfig) (*SQLJob, error) {
+	if config.StatementTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("invalid SQL job %s: statement_timeout_seconds must not be negative", name)
+	}
+	statement, exists := jm.sqlStatement(config.Statement)
+	if !exists {
+		return nil, fmt.Errorf("invalid SQL job %s: SQL statement %q not registered", name, config.Statement)
+	}
+	if len(statement.Tenants) > 0 && !slices.Contains(statement.Tenants, tenantID) {
+		return nil, fmt.Errorf("invalid SQL job %s: SQL statement %q not allowed for tenant %s", name, config.Statement, tenantID)
+	}
+
+	return &SQLJob{
+		name:      name,
+		tenantID:  tenantID,
+		schedule:  schedule,
+		config:    config,
+		statement: statement,
+		pool:      jm.store.ConnPool,
+	}, nil
+}
+
+// SQLJobFactory builds SQL jobs from their spec, whose payload is an SQLJobConfig
+func (jm *JobManager) SQLJobFactory(spec JobSpec) (Job, error) {
+	var config SQLJobConfig
+	if len(spec.Payload) == 0 {
+		return nil, fmt.Errorf("invalid SQL job %s: payload is required", spec.Name)
+	}
+	if err := json.Unmarshal(spec.Payload, &config); err != nil {
+		return nil, fmt.Errorf("invalid SQL job %s: %w", spec.Name, err)
+	}
+	return jm.NewSQLJob(spec.Name, spec.TenantID, spec.Schedule, config)
+}
+
+func (j *SQLJob) Name() string {
+	return j.name
+}
+
+func (j *SQLJob) Lock() string {
+	return j.name
+}
+
+func (j *SQLJob) TenantID() string {
+	return j.tenantID
+}
+
+func (j *SQLJob) Schedule() string {
+	return j.schedule
+}
+
+func (j *SQLJob) NextRunTime() time.Time {
+	next, _ := utils.NextRunTime(j.schedule)
+	return next
+}
+
+func (j *SQLJob) IsLongRunning() bool {
+	return false
+}
+
+func (j *SQLJob) Run(ctx context.Context) error {
+	_, err := j.RunWithResult(ctx)
+	return err
+}
+
+// RunWithResult runs the statement, inside a transaction when configured, and reports the rows it affected
+func (j *SQLJob) RunWithResult(ctx context.Context) (JobResult, error) {
+	args := j.args()
+	var tag pgconn.CommandTag
+	var err error
+	if j.config.Transaction {
+		tag, err = j.execInTransaction(ctx, args)
+	} else {
+		tag, err = j.exec(ctx, args)
+	}
+	if err != nil {
+		return JobResult{}, fmt.Errorf("SQL statement %s failed: %w", j.config.Statement, err)
+	}
+
+	return JobResult{
+		Output: fmt.Sprintf("SQL statement %s affected %d rows", j.config.Statement, tag.RowsAffected()),
+		Data: map[string]interface{}{
+			"statement":     j.config.Statement,
+			"rows_affected": tag.RowsAffected(),
+		},
+	}, nil
+}
+
+// args returns the parameters of the statement: the tenant ID of the job as $1, none for global statements
+func (j *SQLJob) args() []any {
+	if j.statement.Global {
+		return nil
+	}
+	return []any{j.tenantID}
+}
+
+// statementTimeout returns the statement_timeout setting of the job, in milliseconds
+func (j *SQLJob) statementTimeout() string {
+	return strconv.Itoa(j.config.StatementTimeoutSeconds * 1000)
+}
+
+// execInTransaction runs the statement inside a transaction, with a statement_timeout local to it
+func (j *SQLJob) execInTransaction(ctx context.Context, args []any) (pgconn.CommandTag, error) {
+	tx, err := j.pool.Begin(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer tx.Rollback(context.Background())
+
+	if j.config.StatementTimeoutSeconds > 0 {
+		if _, err := tx.Exec(ctx, "SELECT set_config('statement_timeout', $1, true)", j.statementTimeout()); err != nil {
+			return pgconn.CommandTag{}, err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, j.statement.SQL, args...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return tag, nil
+}
+
+// exec runs the statement outside of a transaction, e.g. for statements that cannot run in one.
+// The statement_timeout is set on a dedicated connection and reset before it returns to the pool.
+func (j *SQLJob) exec(ctx context.Context, args []any) (pgconn.CommandTag, error) {
+	if j.config.StatementTimeoutSeconds <= 0 {
+		return j.pool.Exec(ctx, j.statement.SQL, args...)
+	}
+
+	conn, err := j.pool.Acquire(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT set_config('statement_timeout', $1, false)", j.statementTimeout()); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	defer func() {
+		resetCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(resetCtx, "RESET statement_timeout"); err != nil {
+			// Close the connection rather than returning it to the pool with the timeout
+			log.Printf("Error resetting statement_timeout after SQL job %s, closing connection: %v", j.name, err)
+			conn.Conn().Close(resetCtx)
+		}
+	}()
+
+	return conn.Exec(ctx, j.statement.SQL, args...)
+}
@@ -0,0 +1,192 @@
+package cron
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/cto-up/cron-lib/pkg/db"
+)
+
+func TestRegisterSQLStatement(t *testing.T) {
+	tests := []struct {
+		name        string
+		statement   SQLStatement
+		expectError bool
+	}{
+		{
+			name:      "Tenant statement",
+			statement: SQLStatement{SQL: "DELETE FROM sessions WHERE tenant_id = $1"},
+		},
+		{
+			name:      "Global statement allowed for some tenants",
+			statement: SQLStatement{SQL: "REFRESH MATERIALIZED VIEW daily_stats", Global: true, Tenants: []string{"platform"}},
+		},
+		{
+			name:        "Global statement allowed for all tenants",
+			statement:   SQLStatement{SQL: "REFRESH MATERIALIZED VIEW daily_stats", Global: true},
+			expectError: true,
+		},
+		{
+			name:        "Empty statement",
+			statement:   SQLStatement{SQL: "  "},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jm := &JobManager{sqlStatements: make(map[string]SQLStatement)}
+			err := jm.RegisterSQLStatement("statement", tt.statement)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RegisterSQLStatement() error = %v, expectError %v", err, tt.expectError)
+			}
+			if _, registered := jm.sqlStatement("statement"); registered == tt.expectError {
+				t.Errorf("statement registered = %v, want %v", registered, !tt.expectError)
+			}
+		})
+	}
+}
+
+func TestNewSQLJob(t *testing.T) {
+	jm := &JobManager{
+		sqlStatements: make(map[string]SQLStatement),
+		store:         db.NewStore(nil, true),
+	}
+	statements := map[string]SQLStatement{
+		"purge_sessions":   {SQL: "DELETE FROM sessions WHERE tenant_id = $1"},
+		"purge_exports":    {SQL: "DELETE FROM exports WHERE tenant_id = $1", Tenants: []string{"tenant-a"}},
+		"refresh_stats":    {SQL: "REFRESH MATERIALIZED VIEW daily_stats", Global: true, Tenants: []string{"platform"}},
+		"refresh_invoices": {SQL: "REFRESH MATERIALIZED VIEW invoices", Global: true, Tenants: []string{"platform", "tenant-a"}},
+	}
+	for name, statement := range statements {
+		if err := jm.RegisterSQLStatement(name, statement); err != nil {
+			t.Fatalf("RegisterSQLStatement(%s) error = %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		tenantID    string
+		config      SQLJobConfig
+		expectError bool
+		expectArgs  []any
+	}{
+		{
+			name:       "Statement allowed for all tenants",
+			tenantID:   "tenant-b",
+			config:     SQLJobConfig{Statement: "purge_sessions", Transaction: true, StatementTimeoutSeconds: 60},
+			expectArgs: []any{"tenant-b"},
+		},
+		{
+			name:       "Statement allowed for the tenant",
+			tenantID:   "tenant-a",
+			config:     SQLJobConfig{Statement: "purge_exports"},
+			expectArgs: []any{"tenant-a"},
+		},
+		{
+			name:        "Statement not allowed for the tenant",
+			tenantID:    "tenant-b",
+			config:      SQLJobConfig{Statement: "purge_exports"},
+			expectError: true,
+		},
+		{
+			name:     "Global statement allowed for the tenant",
+			tenantID: "platform",
+			config:   SQLJobConfig{Statement: "refresh_stats"},
+		},
+		{
+			name:        "Global statement not allowed for the tenant",
+			tenantID:    "tenant-a",
+			config:      SQLJobConfig{Statement: "refresh_stats"},
+			expectError: true,
+		},
+		{
+			name:     "Global statement allowed for several tenants",
+			tenantID: "tenant-a",
+			config:   SQLJobConfig{Statement: "refresh_invoices"},
+		},
+		{
+			name:        "Statement not registered",
+			tenantID:    "tenant-a",
+			config:      SQLJobConfig{Statement: "DROP TABLE sessions"},
+			expectError: true,
+		},
+		{
+			name:        "Negative statement timeout",
+			tenantID:    "tenant-a",
+			config:      SQLJobConfig{Statement: "purge_sessions", StatementTimeoutSeconds: -1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := jm.NewSQLJob("maintenance", tt.tenantID, "@daily", tt.config)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("NewSQLJob() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil {
+				return
+			}
+			if args := job.args(); !slices.Equal(args, tt.expectArgs) {
+				t.Errorf("args() = %v, want %v", args, tt.expectArgs)
+			}
+		})
+	}
+}
+
+func TestSQLJobFactory(t *testing.T) {
+	jm := &JobManager{
+		sqlStatements: make(map[string]SQLStatement),
+		store:         db.NewStore(nil, true),
+	}
+	if err := jm.RegisterSQLStatement("purge_sessions", SQLStatement{SQL: "DELETE FROM sessions WHERE tenant_id = $1"}); err != nil {
+		t.Fatalf("RegisterSQLStatement() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		payload     string
+		expectError bool
+	}{
+		{
+			name:    "Valid payload",
+			payload: `{"statement":"purge_sessions","transaction":true,"statement_timeout_seconds":60}`,
+		},
+		{
+			name:        "Missing payload",
+			expectError: true,
+		},
+		{
+			name:        "Invalid payload",
+			payload:     `{"statement":42}`,
+			expectError: true,
+		},
+		{
+			name:        "Arbitrary SQL",
+			payload:     `{"statement":"DELETE FROM sessions"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := JobSpec{Name: "maintenance", TenantID: "tenant", Schedule: "@daily"}
+			if tt.payload != "" {
+				spec.Payload = json.RawMessage(tt.payload)
+			}
+
+			job, err := jm.SQLJobFactory(spec)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("SQLJobFactory() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil {
+				return
+			}
+			if job.Name() != spec.Name || job.TenantID() != spec.TenantID || job.Schedule() != spec.Schedule {
+				t.Errorf("SQLJobFactory() built %s (tenant %s, schedule %s)", job.Name(), job.TenantID(), job.Schedule())
+			}
+		})
+	}
+}
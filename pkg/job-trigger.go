@@ -0,0 +1,219 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// runRequestChannel is the PostgreSQL notification channel used to dispatch manual runs
+const runRequestChannel = "cron_run_requests"
+
+// runRequestNotification is the payload sent on runRequestChannel
+type runRequestNotification struct {
+	ID       uuid.UUID `json:"id"`
+	JobName  string    `json:"job_name"`
+	TenantID string    `json:"tenant_id"`
+}
+
+// TriggerJob requests an immediate run of a registered job, outside its schedule.
+// The run goes through the same locking as scheduled runs. When this instance does not
+// hold the job, the request is dispatched to the instances that may hold it.
+// It returns the ID of the run request, which is also the request ID of the audit log.
+func (jm *JobManager) TriggerJob(ctx context.Context, jobName string, tenantID string, userID string) (uuid.UUID, error) {
+	return jm.requestRun(ctx, jobName, tenantID, userID, uuid.Nil)
+}
+
+// IsRegistrationActive reports whether the registration of a job, refreshed by the instances holding
+// the job, was last refreshed within the registration TTL. A run requested for a job whose registration
+// is not active would expire without being claimed, as no running instance holds the job.
+func (jm *JobManager) IsRegistrationActive(lastRegisteredAt time.Time) bool {
+	return time.Since(lastRegisteredAt) < jm.options.registrationTTL
+}
+
+// requestRun creates a run request for a job, part of a workflow run unless workflowRunID is uuid.Nil,
+// and runs it locally or dispatches it to the other instances
+func (jm *JobManager) requestRun(ctx context.Context, jobName string, tenantID string, userID string, workflowRunID uuid.UUID) (uuid.UUID, error) {
+	request, err := jm.store.CreateRunRequest(ctx, repository.CreateRunRequestParams{
+		JobName:       jobName,
+		UserID:        userID,
+		TenantID:      tenantID,
+		WorkflowRunID: pgtype.UUID{Bytes: workflowRunID, Valid: workflowRunID != uuid.Nil},
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create run request for job %s: %w", jobName, err)
+	}
+
+	// Run locally when we hold the job, otherwise notify the other instances
+	if jm.findJob(jobName, tenantID) != nil {
+		jm.handleRunRequest(request.ID, jobName, tenantID)
+		return request.ID, nil
+	}
+
+	payload, err := json.Marshal(runRequestNotification{
+		ID:       request.ID,
+		JobName:  jobName,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to encode run request %s: %w", request.ID, err)
+	}
+
+	err = jm.store.NotifyChannel(ctx, repository.NotifyChannelParams{
+		Channel: runRequestChannel,
+		Payload: string(payload),
+	})
+	if err != nil {
+		// The request is still picked up by the pending requests check
+		log.Printf("Error notifying run request %s for job %s (tenant %s): %v", request.ID, jobName, tenantID, err)
+	}
+
+	return request.ID, nil
+}
+
+// findJob returns the registered job with the given name and tenant, or nil
+func (jm *JobManager) findJob(jobName string, tenantID string) Job {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	key := jobKey(jobName, tenantID)
+	for _, job := range jm.jobs {
+		if jobKey(job.Name(), job.TenantID()) == key {
+			return job
+		}
+	}
+	return nil
+}
+
+// handleRunRequest claims a run request for a job held by this instance and runs the job
+func (jm *JobManager) handleRunRequest(requestID uuid.UUID, jobName string, tenantID string) {
+	job := jm.findJob(jobName, tenantID)
+	if job == nil {
+		// Another instance holds this job
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := jm.store.ClaimRunRequest(ctx, repository.ClaimRunRequestParams{
+		InstanceID: jm.instanceID,
+		ID:         requestID,
+	})
+	if err != nil {
+		if err.Error() != pgx.ErrNoRows.Error() {
+			log.Printf("Error claiming run request %s: %v", requestID, err)
+		}
+		// Otherwise the request was already claimed by another instance
+		return
+	}
+
+	log.Printf("Running job %s for tenant %s on request of user %s", jobName, tenantID, request.UserID)
+	go jm.executeJobWithLock(job, jobExecution{
+		requestID:     request.ID.String(),
+		userID:        request.UserID,
+		scheduledTime: request.ScheduledTime.Time,
+		workflowRunID: request.WorkflowRunID.Bytes,
+	})
+}
+
+// processPendingRunRequests handles run requests whose notification was missed,
+// e.g. while the listener was reconnecting, and the deferred runs that became due
+func (jm *JobManager) processPendingRunRequests() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	jm.mutex.Lock()
+	tenantIDs := jm.tenantIDs()
+	jm.mutex.Unlock()
+
+	if len(tenantIDs) == 0 {
+		return
+	}
+
+	// Requests due for longer are considered expired
+	requests, err := jm.store.ListPendingRunRequests(ctx, repository.ListPendingRunRequestsParams{
+		TenantIds: tenantIDs,
+		DueAfter:  time.Now().Add(-jm.options.runRequestExpiry),
+	})
+	if err != nil {
+		log.Printf("Error loading pending run requests: %v", err)
+		return
+	}
+
+	for _, request := range requests {
+		jm.handleRunRequest(request.ID, request.JobName, request.TenantID)
+	}
+}
+
+// startRunRequestListener listens for run requests dispatched by other instances
+func (jm *JobManager) startRunRequestListener() {
+	ctx, cancel := context.WithCancel(jm.context)
+	jm.stopListener = cancel
+
+	go func() {
+		for {
+			err := jm.listenRunRequests(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Run request listener disconnected, reconnecting in 5 seconds: %v", err)
+
+			select {
+			case <-time.After(5 * time.Second):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	log.Printf("Run request listener started")
+}
+
+// listenRunRequests holds a dedicated connection listening on runRequestChannel until an error occurs
+func (jm *JobManager) listenRunRequests(ctx context.Context) error {
+	conn, err := jm.store.ConnPool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Don't give a listening connection back to the pool
+		if !conn.Conn().IsClosed() {
+			unlistenCtx, unlistenCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer unlistenCancel()
+			if _, err := conn.Exec(unlistenCtx, "UNLISTEN "+runRequestChannel); err != nil {
+				conn.Conn().Close(unlistenCtx)
+			}
+		}
+		conn.Release()
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+runRequestChannel); err != nil {
+		return err
+	}
+
+	// Pick up requests created while we were not listening
+	jm.processPendingRunRequests()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var request runRequestNotification
+		if err := json.Unmarshal([]byte(notification.Payload), &request); err != nil {
+			log.Printf("Error decoding run request notification %q: %v", notification.Payload, err)
+			continue
+		}
+		jm.handleRunRequest(request.ID, request.JobName, request.TenantID)
+	}
+}
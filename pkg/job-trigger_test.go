@@ -0,0 +1,44 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsRegistrationActive(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []JobManagerOption
+		lastRegisteredAt time.Time
+		expectActive     bool
+	}{
+		{
+			name:             "Registration just refreshed",
+			lastRegisteredAt: time.Now(),
+			expectActive:     true,
+		},
+		{
+			name:             "Registration within the default TTL",
+			lastRegisteredAt: time.Now().Add(-23 * time.Hour),
+			expectActive:     true,
+		},
+		{
+			name:             "Registration older than the default TTL",
+			lastRegisteredAt: time.Now().Add(-25 * time.Hour),
+		},
+		{
+			name:             "Registration older than a custom TTL",
+			opts:             []JobManagerOption{WithRegistrationTTL(time.Hour)},
+			lastRegisteredAt: time.Now().Add(-2 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jm := &JobManager{options: newJobManagerOptions(tt.opts...)}
+			if active := jm.IsRegistrationActive(tt.lastRegisteredAt); active != tt.expectActive {
+				t.Errorf("IsRegistrationActive(%s) = %v, want %v", tt.lastRegisteredAt, active, tt.expectActive)
+			}
+		})
+	}
+}
@@ -0,0 +1,445 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+// Failure policies of workflows, stored as failure_policy of cron_workflows
+const (
+	WorkflowFailureStop     = "stop"     // A job that fails or does not run skips the jobs not triggered yet
+	WorkflowFailureContinue = "continue" // The jobs downstream of a job that fails or does not run still run
+)
+
+// WorkflowEdge makes a job of a workflow run after another one
+type WorkflowEdge struct {
+	Upstream   string // Name of the job running first
+	Downstream string // Name of the job triggered once Upstream and its other upstream jobs finished
+}
+
+// Workflow runs registered jobs of a tenant after each other, along the edges of a DAG, e.g. to run
+// a report after the import it reads. A workflow run starts when the root job of the workflow, the only
+// job without upstream job, finishes a run of its own. Each other job is then triggered once all its
+// upstream jobs finished, through the same run requests as TriggerJob.
+type Workflow struct {
+	// Name is the name of the workflow, unique for its tenant
+	Name string
+
+	// TenantID is the tenant of the workflow and of its jobs
+	TenantID string
+
+	// FailurePolicy is WorkflowFailureStop or WorkflowFailureContinue, WorkflowFailureStop when empty
+	FailurePolicy string
+
+	// Edges are the edges of the DAG, between the names of the jobs of the workflow
+	Edges []WorkflowEdge
+}
+
+// RegisterWorkflow stores a workflow, replacing the edges and failure policy of the workflow with the
+// same name and tenant. It can be called by every instance, the workflow runs are shared.
+func (jm *JobManager) RegisterWorkflow(ctx context.Context, workflow Workflow) error {
+	if err := workflow.validate(); err != nil {
+		return fmt.Errorf("invalid workflow %s: %w", workflow.Name, err)
+	}
+	registered, err := jm.store.ListRegisteredJobsByTenants(ctx, []string{workflow.TenantID})
+	if err != nil {
+		return fmt.Errorf("failed to load the registered jobs of tenant %s: %w", workflow.TenantID, err)
+	}
+	// A job that is not registered is never run, its workflow runs would stay running
+	if unregistered := workflow.unregisteredJobs(registered); len(unregistered) > 0 {
+		return fmt.Errorf("invalid workflow %s: jobs %v are not registered for tenant %s", workflow.Name, unregistered, workflow.TenantID)
+	}
+
+	failurePolicy := workflow.FailurePolicy
+	if failurePolicy == "" {
+		failurePolicy = WorkflowFailureStop
+	}
+
+	err = jm.store.ExecTx(ctx, func(q *repository.Queries) error {
+		row, err := q.UpsertWorkflow(ctx, repository.UpsertWorkflowParams{
+			Name:          workflow.Name,
+			FailurePolicy: failurePolicy,
+			TenantID:      workflow.TenantID,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.DeleteWorkflowEdges(ctx, row.ID); err != nil {
+			return err
+		}
+		for _, edge := range workflow.Edges {
+			err := q.CreateWorkflowEdge(ctx, repository.CreateWorkflowEdgeParams{
+				WorkflowID:    row.ID,
+				UpstreamJob:   edge.Upstream,
+				DownstreamJob: edge.Downstream,
+				TenantID:      workflow.TenantID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register workflow %s: %w", workflow.Name, err)
+	}
+
+	log.Printf("Workflow %s registered for tenant %s", workflow.Name, workflow.TenantID)
+	return nil
+}
+
+// validate checks that the edges of the workflow form a DAG with a single root
+func (w Workflow) validate() error {
+	if w.Name == "" {
+		return errors.New("name is required")
+	}
+	if w.FailurePolicy != "" && w.FailurePolicy != WorkflowFailureStop && w.FailurePolicy != WorkflowFailureContinue {
+		return fmt.Errorf("unknown failure policy %q", w.FailurePolicy)
+	}
+	if len(w.Edges) == 0 {
+		return errors.New("at least one edge is required")
+	}
+
+	// Count the upstream jobs of each job, then remove the jobs without any until none is left
+	upstreamCounts := make(map[string]int)
+	downstreamJobs := make(map[string][]string)
+	for _, edge := range w.Edges {
+		if edge.Upstream == "" || edge.Downstream == "" {
+			return errors.New("edges must name both of their jobs")
+		}
+		if edge.Upstream == edge.Downstream {
+			return fmt.Errorf("job %s cannot run after itself", edge.Upstream)
+		}
+		if _, exists := upstreamCounts[edge.Upstream]; !exists {
+			upstreamCounts[edge.Upstream] = 0
+		}
+		upstreamCounts[edge.Downstream]++
+		downstreamJobs[edge.Upstream] = append(downstreamJobs[edge.Upstream], edge.Downstream)
+	}
+
+	var ready []string
+	for jobName, count := range upstreamCounts {
+		if count == 0 {
+			ready = append(ready, jobName)
+		}
+	}
+	if len(ready) != 1 {
+		sort.Strings(ready)
+		return fmt.Errorf("a single root job is expected, found %v", ready)
+	}
+
+	visited := 0
+	for len(ready) > 0 {
+		jobName := ready[0]
+		ready = ready[1:]
+		visited++
+		for _, downstream := range downstreamJobs[jobName] {
+			upstreamCounts[downstream]--
+			if upstreamCounts[downstream] == 0 {
+				ready = append(ready, downstream)
+			}
+		}
+	}
+	if visited != len(upstreamCounts) {
+		return errors.New("edges form a cycle")
+	}
+	return nil
+}
+
+// unregisteredJobs returns the jobs named by the edges of the workflow that are not among the
+// registered jobs, sorted
+func (w Workflow) unregisteredJobs(registered []repository.CronRegisteredJob) []string {
+	names := make(map[string]bool, len(registered))
+	for _, job := range registered {
+		if job.TenantID == w.TenantID {
+			names[job.JobName] = true
+		}
+	}
+
+	var unregistered []string
+	for _, edge := range w.Edges {
+		for _, jobName := range []string{edge.Upstream, edge.Downstream} {
+			if !names[jobName] {
+				names[jobName] = true
+				unregistered = append(unregistered, jobName)
+			}
+		}
+	}
+	sort.Strings(unregistered)
+	return unregistered
+}
+
+// advanceWorkflows records the end of a run in the workflows of its job: the node of the job in its
+// workflow run, or a new run of each workflow whose root is the job. Runs that did not happen, e.g.
+// because another instance ran the job, start no workflow run. A deferred run of a node leaves it
+// running, the deferred run request carrying the workflow run.
+func (jm *JobManager) advanceWorkflows(job Job, execution jobExecution, auditLogID uuid.UUID, status string) {
+	if status == "deferred" {
+		return
+	}
+	if execution.workflowRunID != uuid.Nil {
+		jm.finishWorkflowNode(execution.workflowRunID, job.Name(), job.TenantID(), auditLogID, status)
+		return
+	}
+	if status == "skipped" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	workflows, err := jm.store.ListRootWorkflows(ctx, repository.ListRootWorkflowsParams{
+		TenantID: job.TenantID(),
+		JobName:  job.Name(),
+	})
+	if err != nil {
+		log.Printf("Error loading workflows of job %s (tenant %s): %v", job.Name(), job.TenantID(), err)
+		return
+	}
+
+	for _, workflow := range workflows {
+		jm.startWorkflowRun(workflow, job.Name(), auditLogID, status)
+	}
+}
+
+// startWorkflowRun starts a run of a workflow whose root job finished with the given status
+func (jm *JobManager) startWorkflowRun(workflow repository.CronWorkflow, rootJob string, auditLogID uuid.UUID, status string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var run repository.CronWorkflowRun
+	err := jm.store.ExecTx(ctx, func(q *repository.Queries) error {
+		var err error
+		run, err = q.CreateWorkflowRun(ctx, repository.CreateWorkflowRunParams{
+			WorkflowID: workflow.ID,
+			TenantID:   workflow.TenantID,
+		})
+		if err != nil {
+			return err
+		}
+		return q.CreateWorkflowRunNodes(ctx, repository.CreateWorkflowRunNodesParams{
+			WorkflowRunID: run.ID,
+			TenantID:      workflow.TenantID,
+			WorkflowID:    workflow.ID,
+		})
+	})
+	if err != nil {
+		log.Printf("Error starting run of workflow %s (tenant %s): %v", workflow.Name, workflow.TenantID, err)
+		return
+	}
+
+	// The run of the root job started before the workflow run
+	if auditLogID != uuid.Nil {
+		err := jm.store.SetJobAuditLogWorkflowRun(ctx, repository.SetJobAuditLogWorkflowRunParams{
+			WorkflowRunID: run.ID,
+			ID:            auditLogID,
+			TenantID:      workflow.TenantID,
+		})
+		if err != nil {
+			log.Printf("Error linking job audit log %s to workflow run %s: %v", auditLogID, run.ID, err)
+		}
+	}
+
+	log.Printf("Workflow %s (tenant %s) started run %s after job %s", workflow.Name, workflow.TenantID, run.ID, rootJob)
+	jm.finishWorkflowNode(run.ID, rootJob, workflow.TenantID, auditLogID, status)
+}
+
+// finishWorkflowNode records the status of a job of a workflow run, then triggers the jobs whose
+// upstream jobs all finished and completes the workflow run once none is left
+func (jm *JobManager) finishWorkflowNode(runID uuid.UUID, jobName string, tenantID string, auditLogID uuid.UUID, status string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	updated, err := jm.store.UpdateWorkflowRunNode(ctx, repository.UpdateWorkflowRunNodeParams{
+		Status:        status,
+		AuditLogID:    pgtype.UUID{Bytes: auditLogID, Valid: auditLogID != uuid.Nil},
+		WorkflowRunID: runID,
+		JobName:       jobName,
+	})
+	if err != nil {
+		log.Printf("Error recording job %s in workflow run %s: %v", jobName, runID, err)
+		return
+	}
+	// The job already finished, e.g. failed as stuck by failStuckWorkflowNodes, which advanced the run
+	if updated.RowsAffected() == 0 {
+		return
+	}
+
+	jm.continueWorkflowRun(ctx, runID, jobName, tenantID, status)
+}
+
+// continueWorkflowRun advances a workflow run once one of its jobs finished with status, skipping
+// the jobs not triggered yet when the job did not complete and the workflow stops on failure
+func (jm *JobManager) continueWorkflowRun(ctx context.Context, runID uuid.UUID, jobName string, tenantID string, status string) {
+	run, err := jm.store.GetWorkflowRunByID(ctx, repository.GetWorkflowRunByIDParams{
+		ID:       runID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		log.Printf("Error loading workflow run %s: %v", runID, err)
+		return
+	}
+	if run.Status != "running" {
+		return
+	}
+
+	workflow, err := jm.store.GetWorkflowByID(ctx, repository.GetWorkflowByIDParams{
+		ID:       run.WorkflowID,
+		TenantID: tenantID,
+	})
+	if err != nil {
+		log.Printf("Error loading workflow of run %s: %v", runID, err)
+		return
+	}
+	if status != "completed" && workflow.FailurePolicy == WorkflowFailureStop {
+		log.Printf("Job %s did not complete, stopping run %s of workflow %s (tenant %s)", jobName, runID, workflow.Name, tenantID)
+		if err := jm.store.SkipPendingWorkflowRunNodes(ctx, runID); err != nil {
+			log.Printf("Error skipping the jobs of workflow run %s: %v", runID, err)
+		}
+	}
+
+	jm.advanceWorkflowRun(ctx, run, workflow)
+}
+
+// advanceWorkflowRun triggers the pending jobs of a workflow run whose upstream jobs all finished,
+// and completes the run when all its jobs finished
+func (jm *JobManager) advanceWorkflowRun(ctx context.Context, run repository.CronWorkflowRun, workflow repository.CronWorkflow) {
+	nodes, err := jm.store.ListWorkflowRunNodes(ctx, repository.ListWorkflowRunNodesParams{
+		WorkflowRunID: run.ID,
+		TenantID:      run.TenantID,
+	})
+	if err != nil {
+		log.Printf("Error loading the jobs of workflow run %s: %v", run.ID, err)
+		return
+	}
+	edges, err := jm.store.ListWorkflowEdges(ctx, repository.ListWorkflowEdgesParams{
+		WorkflowID: workflow.ID,
+		TenantID:   workflow.TenantID,
+	})
+	if err != nil {
+		log.Printf("Error loading the edges of workflow %s: %v", workflow.Name, err)
+		return
+	}
+
+	statuses := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		statuses[node.JobName] = node.Status
+	}
+
+	for _, jobName := range readyWorkflowNodes(statuses, edges) {
+		// Only one of the instances finishing the upstream jobs triggers the job
+		claimed, err := jm.store.ClaimWorkflowRunNode(ctx, repository.ClaimWorkflowRunNodeParams{
+			WorkflowRunID: run.ID,
+			JobName:       jobName,
+		})
+		if err != nil {
+			log.Printf("Error claiming job %s of workflow run %s: %v", jobName, run.ID, err)
+			continue
+		}
+		if claimed.RowsAffected() == 0 {
+			continue
+		}
+		statuses[jobName] = "running"
+
+		if _, err := jm.requestRun(ctx, jobName, run.TenantID, systemUserID, run.ID); err != nil {
+			log.Printf("Error triggering job %s of workflow run %s: %v", jobName, run.ID, err)
+			// Recorded as failed, which advances the workflow run again
+			jm.finishWorkflowNode(run.ID, jobName, run.TenantID, uuid.Nil, "failed")
+			return
+		}
+	}
+
+	status, finished := workflowRunStatus(statuses)
+	if !finished {
+		return
+	}
+	completed, err := jm.store.CompleteWorkflowRun(ctx, repository.CompleteWorkflowRunParams{
+		Status: status,
+		ID:     run.ID,
+	})
+	if err != nil {
+		log.Printf("Error completing workflow run %s: %v", run.ID, err)
+	} else if completed.RowsAffected() > 0 {
+		log.Printf("Run %s of workflow %s (tenant %s) %s", run.ID, workflow.Name, run.TenantID, status)
+	}
+}
+
+// failStuckWorkflowNodes fails the triggered jobs of workflow runs that will never finish, so their
+// runs advance: their run request expired without being claimed, e.g. because no instance holds the
+// job, or the run claiming it stopped without finishing, e.g. because its instance crashed.
+func (jm *JobManager) failStuckWorkflowNodes() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	jm.mutex.Lock()
+	tenantIDs := jm.tenantIDs()
+	jm.mutex.Unlock()
+
+	if len(tenantIDs) == 0 {
+		return
+	}
+
+	now := time.Now()
+	nodes, err := jm.store.FailStuckWorkflowRunNodes(ctx, repository.FailStuckWorkflowRunNodesParams{
+		TenantIds:     tenantIDs,
+		ExpiredBefore: now.Add(-jm.options.runRequestExpiry),
+		StaleBefore:   now.Add(-jm.options.staleLockAfter),
+	})
+	if err != nil {
+		log.Printf("Error failing stuck workflow jobs: %v", err)
+		return
+	}
+
+	for _, node := range nodes {
+		log.Printf("Job %s of workflow run %s (tenant %s) never finished, recorded as failed", node.JobName, node.WorkflowRunID, node.TenantID)
+		jm.continueWorkflowRun(ctx, node.WorkflowRunID, node.JobName, node.TenantID, "failed")
+	}
+}
+
+// readyWorkflowNodes returns the pending jobs whose upstream jobs all finished, sorted
+func readyWorkflowNodes(statuses map[string]string, edges []repository.CronWorkflowEdge) []string {
+	blocked := make(map[string]bool)
+	for _, edge := range edges {
+		if !isWorkflowNodeFinished(statuses[edge.UpstreamJob]) {
+			blocked[edge.DownstreamJob] = true
+		}
+	}
+
+	var ready []string
+	for jobName, status := range statuses {
+		if status == "pending" && !blocked[jobName] {
+			ready = append(ready, jobName)
+		}
+	}
+	sort.Strings(ready)
+	return ready
+}
+
+// workflowRunStatus returns the final status of a workflow run, and whether all its jobs finished.
+// The run failed when any of its jobs did not complete.
+func workflowRunStatus(statuses map[string]string) (string, bool) {
+	status := "completed"
+	for _, nodeStatus := range statuses {
+		if !isWorkflowNodeFinished(nodeStatus) {
+			return "", false
+		}
+		if nodeStatus != "completed" {
+			status = "failed"
+		}
+	}
+	return status, true
+}
+
+// isWorkflowNodeFinished reports whether a job of a workflow run has a final status
+func isWorkflowNodeFinished(status string) bool {
+	return status == "completed" || status == "failed" || status == "skipped"
+}
@@ -0,0 +1,181 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cto-up/cron-lib/pkg/db/repository"
+)
+
+func TestWorkflowValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		workflow    Workflow
+		expectError bool
+	}{
+		{
+			name: "Chain",
+			workflow: Workflow{Name: "nightly", Edges: []WorkflowEdge{
+				{Upstream: "import", Downstream: "aggregate"},
+				{Upstream: "aggregate", Downstream: "report"},
+			}},
+		},
+		{
+			name: "Diamond",
+			workflow: Workflow{Name: "nightly", FailurePolicy: WorkflowFailureContinue, Edges: []WorkflowEdge{
+				{Upstream: "import", Downstream: "aggregate"},
+				{Upstream: "import", Downstream: "index"},
+				{Upstream: "aggregate", Downstream: "report"},
+				{Upstream: "index", Downstream: "report"},
+			}},
+		},
+		{
+			name:        "Missing name",
+			workflow:    Workflow{Edges: []WorkflowEdge{{Upstream: "import", Downstream: "report"}}},
+			expectError: true,
+		},
+		{
+			name:        "No edges",
+			workflow:    Workflow{Name: "nightly"},
+			expectError: true,
+		},
+		{
+			name:        "Unknown failure policy",
+			workflow:    Workflow{Name: "nightly", FailurePolicy: "retry", Edges: []WorkflowEdge{{Upstream: "import", Downstream: "report"}}},
+			expectError: true,
+		},
+		{
+			name:        "Self edge",
+			workflow:    Workflow{Name: "nightly", Edges: []WorkflowEdge{{Upstream: "import", Downstream: "import"}}},
+			expectError: true,
+		},
+		{
+			name: "Several roots",
+			workflow: Workflow{Name: "nightly", Edges: []WorkflowEdge{
+				{Upstream: "import", Downstream: "report"},
+				{Upstream: "index", Downstream: "report"},
+			}},
+			expectError: true,
+		},
+		{
+			name: "Cycle",
+			workflow: Workflow{Name: "nightly", Edges: []WorkflowEdge{
+				{Upstream: "import", Downstream: "aggregate"},
+				{Upstream: "aggregate", Downstream: "report"},
+				{Upstream: "report", Downstream: "aggregate"},
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.validate()
+			if (err != nil) != tt.expectError {
+				t.Errorf("validate() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestWorkflowUnregisteredJobs(t *testing.T) {
+	workflow := Workflow{Name: "nightly", TenantID: "tenant-a", Edges: []WorkflowEdge{
+		{Upstream: "import", Downstream: "report"},
+		{Upstream: "import", Downstream: "index"},
+		{Upstream: "index", Downstream: "report"},
+	}}
+	registered := func(tenantID string, jobNames ...string) []repository.CronRegisteredJob {
+		var jobs []repository.CronRegisteredJob
+		for _, jobName := range jobNames {
+			jobs = append(jobs, repository.CronRegisteredJob{JobName: jobName, TenantID: tenantID})
+		}
+		return jobs
+	}
+
+	tests := []struct {
+		name               string
+		registered         []repository.CronRegisteredJob
+		expectUnregistered []string
+	}{
+		{
+			name:       "All jobs registered",
+			registered: registered("tenant-a", "import", "index", "report", "cleanup"),
+		},
+		{
+			name:               "Jobs missing",
+			registered:         registered("tenant-a", "index"),
+			expectUnregistered: []string{"import", "report"},
+		},
+		{
+			name:               "Jobs registered for another tenant",
+			registered:         append(registered("tenant-a", "import", "index"), registered("tenant-b", "report")...),
+			expectUnregistered: []string{"report"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unregistered := workflow.unregisteredJobs(tt.registered)
+			if !reflect.DeepEqual(unregistered, tt.expectUnregistered) {
+				t.Errorf("unregisteredJobs() = %v, want %v", unregistered, tt.expectUnregistered)
+			}
+		})
+	}
+}
+
+func TestReadyWorkflowNodes(t *testing.T) {
+	edges := []repository.CronWorkflowEdge{
+		{UpstreamJob: "import", DownstreamJob: "aggregate"},
+		{UpstreamJob: "import", DownstreamJob: "index"},
+		{UpstreamJob: "aggregate", DownstreamJob: "report"},
+		{UpstreamJob: "index", DownstreamJob: "report"},
+	}
+
+	tests := []struct {
+		name           string
+		statuses       map[string]string
+		expectReady    []string
+		expectStatus   string
+		expectFinished bool
+	}{
+		{
+			name:        "Root completed",
+			statuses:    map[string]string{"import": "completed", "aggregate": "pending", "index": "pending", "report": "pending"},
+			expectReady: []string{"aggregate", "index"},
+		},
+		{
+			name:     "Waiting for an upstream job",
+			statuses: map[string]string{"import": "completed", "aggregate": "completed", "index": "running", "report": "pending"},
+		},
+		{
+			name:        "Upstream job failed",
+			statuses:    map[string]string{"import": "completed", "aggregate": "completed", "index": "failed", "report": "pending"},
+			expectReady: []string{"report"},
+		},
+		{
+			name:           "All completed",
+			statuses:       map[string]string{"import": "completed", "aggregate": "completed", "index": "completed", "report": "completed"},
+			expectStatus:   "completed",
+			expectFinished: true,
+		},
+		{
+			name:           "Stopped after a failure",
+			statuses:       map[string]string{"import": "completed", "aggregate": "failed", "index": "completed", "report": "skipped"},
+			expectStatus:   "failed",
+			expectFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready := readyWorkflowNodes(tt.statuses, edges)
+			if !reflect.DeepEqual(ready, tt.expectReady) {
+				t.Errorf("readyWorkflowNodes() = %v, want %v", ready, tt.expectReady)
+			}
+			status, finished := workflowRunStatus(tt.statuses)
+			if status != tt.expectStatus || finished != tt.expectFinished {
+				t.Errorf("workflowRunStatus() = %q, %v, want %q, %v", status, finished, tt.expectStatus, tt.expectFinished)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// hashedFieldRanges are the ranges H tokens spread fire times within, per field of a 6-field schedule.
+// Days of month stop at 28 so that the hashed day exists in every month.
+var hashedFieldRanges = [][2]int{{0, 59}, {0, 59}, {0, 23}, {1, 28}, {1, 12}, {0, 6}}
+
+// ScheduleSeed returns the seed H tokens of the schedule of a job are hashed from
+func ScheduleSeed(tenantID string, jobName string) string {
+	return tenantID + ":" + jobName
+}
+
+// IsHashedSchedule returns whether a cron schedule string uses H tokens
+func IsHashedSchedule(schedule string) bool {
+	fields, _, ok := hashableFields(schedule)
+	if !ok {
+		return false
+	}
+	for _, field := range fields {
+		for _, part := range strings.Split(field, ",") {
+			if isHashedToken(part) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveHashedSchedule replaces the H tokens of a cron schedule string with values hashed from seed,
+// so that jobs sharing a schedule fire at different but stable times:
+//   - H is a value within the range of the field, e.g. H * * * * * fires once a minute at a hashed second
+//   - H(a-b) is a value within a and b, e.g. 0 H(0-29) 2 * * * fires between 02:00 and 02:29
+//   - H/n fires every n units starting at a hashed offset below n, e.g. 0 H/15 * * * * fires every 15 minutes
+//
+// Schedules without H tokens, descriptors and recurrence rules are returned unchanged.
+func ResolveHashedSchedule(schedule string, seed string) (string, error) {
+	fields, fieldIndex, ok := hashableFields(schedule)
+	if !ok {
+		return schedule, nil
+	}
+
+	resolved := false
+	for i, field := range fields {
+		parts := strings.Split(field, ",")
+		for j, part := range parts {
+			if !isHashedToken(part) {
+				continue
+			}
+			value, err := resolveHashedToken(part, hashedFieldRanges[fieldIndex+i], hashSeed(seed, scheduleFieldNames[fieldIndex+i]))
+			if err != nil {
+				return "", fmt.Errorf("invalid %s field %q: %w", scheduleFieldNames[fieldIndex+i], field, err)
+			}
+			parts[j] = value
+			resolved = true
+		}
+		fields[i] = strings.Join(parts, ",")
+	}
+	if !resolved {
+		return schedule, nil
+	}
+
+	prefix, _, _ := splitTimeZone(schedule)
+	if prefix != "" {
+		return prefix + " " + strings.Join(fields, " "), nil
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// hashableFields returns the fields of a 5 or 6-field cron schedule string, with the index of its first
+// field in scheduleFieldNames. It returns false for other schedules.
+func hashableFields(schedule string) ([]string, int, bool) {
+	if IsRRuleSchedule(schedule) {
+		return nil, 0, false
+	}
+	_, _, expression := splitTimeZone(schedule)
+	fields := strings.Fields(expression)
+	switch {
+	case len(fields) == 0 || strings.HasPrefix(fields[0], "@"):
+		return nil, 0, false
+	case len(fields) == len(scheduleFieldNames):
+		return fields, 0, true
+	case len(fields) == len(scheduleFieldNames)-1:
+		return fields, 1, true
+	default:
+		return nil, 0, false
+	}
+}
+
+// isHashedToken returns whether a part of a field is an H token
+func isHashedToken(part string) bool {
+	return part == "H" || strings.HasPrefix(part, "H(") || strings.HasPrefix(part, "H/")
+}
+
+// resolveHashedToken returns the value of an H token within the given field range
+func resolveHashedToken(token string, fieldRange [2]int, hash uint64) (string, error) {
+	low, high := fieldRange[0], fieldRange[1]
+
+	rest := token[1:]
+	if strings.HasPrefix(rest, "(") {
+		end := strings.Index(rest, ")")
+		if end < 0 {
+			return "", errors.New("missing ) after H(")
+		}
+		bounds := strings.SplitN(rest[1:end], "-", 2)
+		if len(bounds) != 2 {
+			return "", errors.New("expected H(a-b)")
+		}
+		a, errA := strconv.Atoi(bounds[0])
+		b, errB := strconv.Atoi(bounds[1])
+		if errA != nil || errB != nil {
+			return "", errors.New("expected numbers in H(a-b)")
+		}
+		if a < low || b > high || a > b {
+			return "", fmt.Errorf("range %d-%d is not within %d-%d", a, b, low, high)
+		}
+		low, high = a, b
+		rest = rest[end+1:]
+	}
+
+	if rest == "" {
+		return strconv.Itoa(low + int(hash%uint64(high-low+1))), nil
+	}
+
+	if !strings.HasPrefix(rest, "/") {
+		return "", fmt.Errorf("unexpected %q after H", rest)
+	}
+	step, err := strconv.Atoi(rest[1:])
+	if err != nil || step < 1 {
+		return "", fmt.Errorf("invalid step %q", rest[1:])
+	}
+	span := min(step, high-low+1)
+	return fmt.Sprintf("%d-%d/%d", low+int(hash%uint64(span)), high, step), nil
+}
+
+// hashSeed hashes the seed for a field, so that the fields of a schedule get independent values
+func hashSeed(seed string, fieldName string) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(seed))
+	hash.Write([]byte{0})
+	hash.Write([]byte(fieldName))
+	return hash.Sum64()
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveHashedSchedule(t *testing.T) {
+	tests := []struct {
+		name         string
+		cronSchedule string
+		expectError  bool
+		// check validates the fire times of the resolved schedule
+		check func(next time.Time) bool
+	}{
+		{
+			name:         "Hashed second",
+			cronSchedule: "H * * * * *",
+		},
+		{
+			name:         "Hashed minute within a range",
+			cronSchedule: "0 H(0-29) 2 * * *",
+			check: func(next time.Time) bool {
+				return next.Hour() == 2 && next.Minute() < 30 && next.Second() == 0
+			},
+		},
+		{
+			name:         "Hashed step",
+			cronSchedule: "0 H/15 * * * *",
+			check: func(next time.Time) bool {
+				return next.Second() == 0
+			},
+		},
+		{
+			name:         "Hashed day of month of a 5-field schedule",
+			cronSchedule: "0 3 H * *",
+			check: func(next time.Time) bool {
+				return next.Day() <= 28 && next.Hour() == 3
+			},
+		},
+		{
+			name:         "Range out of the field",
+			cronSchedule: "0 H(30-70) * * * *",
+			expectError:  true,
+		},
+		{
+			name:         "Invalid step",
+			cronSchedule: "0 H/0 * * * *",
+			expectError:  true,
+		},
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveHashedSchedule(tt.cronSchedule, ScheduleSeed("tenant-1", "echo"))
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ResolveHashedSchedule() expected an error for schedule '%s', but got none", tt.cronSchedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveHashedSchedule() for schedule '%s' returned an unexpected error: %v", tt.cronSchedule, err)
+			}
+
+			// The same seed resolves to the same schedule
+			again, _ := ResolveHashedSchedule(tt.cronSchedule, ScheduleSeed("tenant-1", "echo"))
+			if again != resolved {
+				t.Errorf("ResolveHashedSchedule() for schedule '%s' is not deterministic: '%s' then '%s'", tt.cronSchedule, resolved, again)
+			}
+
+			schedule, err := ParseSchedule(resolved)
+			if err != nil {
+				t.Fatalf("ParseSchedule() for resolved schedule '%s' returned an unexpected error: %v", resolved, err)
+			}
+			next := InLocation(schedule, time.UTC).Next(from)
+			if tt.check != nil && !tt.check(next) {
+				t.Errorf("Resolved schedule '%s' fires at %s", resolved, next)
+			}
+		})
+	}
+}
+
+func TestResolveHashedScheduleSpreads(t *testing.T) {
+	seconds := make(map[string]bool)
+	for _, tenantID := range []string{"tenant-1", "tenant-2", "tenant-3", "tenant-4", "tenant-5", "tenant-6"} {
+		resolved, err := ResolveHashedSchedule("H * * * * *", ScheduleSeed(tenantID, "echo"))
+		if err != nil {
+			t.Fatalf("ResolveHashedSchedule() returned an unexpected error: %v", err)
+		}
+		seconds[resolved] = true
+	}
+	if len(seconds) < 2 {
+		t.Errorf("ResolveHashedSchedule() resolved the schedules of 6 tenants to %d distinct schedules", len(seconds))
+	}
+}
+
+func TestIsHashedSchedule(t *testing.T) {
+	tests := map[string]bool{
+		"H * * * * *":          true,
+		"0 0,H(30-45) * * * *": true,
+		"0 0 8 * * THU":        false,
+		"@hourly":              false,
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=HOURLY": false,
+	}
+
+	for schedule, expected := range tests {
+		if hashed := IsHashedSchedule(schedule); hashed != expected {
+			t.Errorf("IsHashedSchedule() for schedule '%s' returned %v, expected %v", schedule, hashed, expected)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// Schedule types, stored as schedule_type of registered jobs
+const (
+	ScheduleTypeCron  = "cron"  // Cron expressions, descriptors and @every schedules
+	ScheduleTypeRRule = "rrule" // RFC 5545 recurrence rules
+)
+
+// rruleProperties are the properties a recurrence rule schedule starts with
+var rruleProperties = []string{"DTSTART", "RRULE", "RDATE", "EXDATE"}
+
+// RRuleSchedule is a schedule defined by an RFC 5545 recurrence rule, e.g. for business calendars:
+//
+//	DTSTART;TZID=Europe/Paris:20240101T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1
+//	EXDATE;TZID=Europe/Paris:20241231T090000
+//
+// It implements cron.Schedule.
+type RRuleSchedule struct {
+	set      *rrule.Set
+	lines    []string
+	floating bool // DTSTART has no time zone and is evaluated in the time zone of the schedule
+}
+
+// Next implements cron.Schedule, returning the zero time when the rule has no more occurrences
+func (s *RRuleSchedule) Next(t time.Time) time.Time {
+	return s.set.After(t, false)
+}
+
+// IsRRuleSchedule returns whether a schedule string is a recurrence rule rather than a cron schedule
+func IsRRuleSchedule(schedule string) bool {
+	lines := rruleLines(schedule)
+	if len(lines) == 0 {
+		return false
+	}
+	first := strings.ToUpper(lines[0])
+	for _, property := range rruleProperties {
+		if strings.HasPrefix(first, property+":") || strings.HasPrefix(first, property+";") {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleTypeOf returns the type of a schedule string, ScheduleTypeRRule or ScheduleTypeCron
+func ScheduleTypeOf(schedule string) string {
+	if IsRRuleSchedule(schedule) {
+		return ScheduleTypeRRule
+	}
+	return ScheduleTypeCron
+}
+
+// ParseRRuleSchedule parses a recurrence rule schedule, one property per line among DTSTART, RRULE,
+// RDATE and EXDATE, in any order. DTSTART is required, as it anchors the occurrences. Dates without a time zone
+// are evaluated in loc, or in the time zone of the server when loc is nil.
+func ParseRRuleSchedule(schedule string, loc *time.Location) (*RRuleSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	lines := rruleLines(schedule)
+	set, err := rrule.StrSliceToRRuleSetInLoc(lines, loc)
+	if err != nil {
+		return nil, err
+	}
+	if set.GetDTStart().IsZero() {
+		return nil, errors.New("recurrence rule schedules require a DTSTART")
+	}
+	if set.GetRRule() == nil && len(set.GetRDate()) == 0 {
+		return nil, errors.New("recurrence rule schedules require an RRULE or RDATE")
+	}
+
+	return &RRuleSchedule{
+		set:      set,
+		lines:    lines,
+		floating: hasFloatingDTStart(lines),
+	}, nil
+}
+
+// hasFloatingDTStart reports whether the DTSTART of a recurrence rule schedule, wherever its line is,
+// has neither a TZID nor a UTC time
+func hasFloatingDTStart(lines []string) bool {
+	for _, line := range lines {
+		line = strings.ToUpper(line)
+		if strings.HasPrefix(line, "DTSTART:") {
+			return !strings.HasSuffix(line, "Z")
+		}
+	}
+	return false
+}
+
+// inLocation returns the schedule evaluated in the given time zone when its DTSTART has no time zone
+func (s *RRuleSchedule) inLocation(loc *time.Location) *RRuleSchedule {
+	if !s.floating || loc == nil {
+		return s
+	}
+	located, err := ParseRRuleSchedule(strings.Join(s.lines, "\n"), loc)
+	if err != nil {
+		return s
+	}
+	return located
+}
+
+// normalizeRRuleSchedule returns a recurrence rule schedule with one trimmed property per line
+func normalizeRRuleSchedule(schedule string) (string, error) {
+	if _, err := ParseRRuleSchedule(schedule, nil); err != nil {
+		return "", fmt.Errorf("failed to parse recurrence rule schedule '%s': %w", schedule, err)
+	}
+	return strings.Join(rruleLines(schedule), "\n"), nil
+}
+
+// rruleLines returns the trimmed non-empty lines of a recurrence rule schedule, its DTSTART first
+// as the parser only reads it from the first line
+func rruleLines(schedule string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(schedule, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(line), "DTSTART") {
+			lines = append([]string{line}, lines...)
+		} else {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
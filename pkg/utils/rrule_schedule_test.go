@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRRuleSchedule(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		schedule    string
+		from        time.Time
+		expected    []time.Time
+		expectError bool
+	}{
+		{
+			name: "Last business day of the month",
+			schedule: `DTSTART;TZID=Europe/Paris:20240101T090000
+				RRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1`,
+			from: time.Date(2024, 2, 1, 0, 0, 0, 0, paris),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 9, 0, 0, 0, paris),
+				time.Date(2024, 3, 29, 9, 0, 0, 0, paris),
+				time.Date(2024, 4, 30, 9, 0, 0, 0, paris),
+			},
+		},
+		{
+			name: "Every second Tuesday with an excluded date",
+			schedule: `DTSTART:20240101T100000Z
+				RRULE:FREQ=MONTHLY;BYDAY=+2TU
+				EXDATE:20240312T100000Z`,
+			from: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 13, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 9, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "Missing DTSTART",
+			schedule:    "RRULE:FREQ=DAILY",
+			expectError: true,
+		},
+		{
+			name:        "Invalid rule",
+			schedule:    "DTSTART:20240101T100000Z\nRRULE:FREQ=SOMETIMES",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !IsRRuleSchedule(tt.schedule) {
+				t.Fatalf("IsRRuleSchedule() for schedule '%s' returned false", tt.schedule)
+			}
+
+			schedule, err := ParseSchedule(tt.schedule)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseSchedule() expected an error for schedule '%s', but got none", tt.schedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSchedule() for schedule '%s' returned an unexpected error: %v", tt.schedule, err)
+			}
+
+			next := tt.from
+			for i, expected := range tt.expected {
+				next = schedule.Next(next)
+				if !next.Equal(expected) {
+					t.Errorf("Occurrence %d of schedule '%s' is %s, expected %s", i, tt.schedule, next, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRRuleScheduleInLocation(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Time zone database not available: %v", err)
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule string
+		expected time.Time
+	}{
+		{
+			name:     "Floating DTSTART",
+			schedule: "DTSTART:20240101T090000\nRRULE:FREQ=DAILY",
+			expected: time.Date(2024, 6, 2, 9, 0, 0, 0, paris),
+		},
+		{
+			name:     "Floating DTSTART after RRULE",
+			schedule: "RRULE:FREQ=DAILY\nDTSTART:20240101T090000",
+			expected: time.Date(2024, 6, 2, 9, 0, 0, 0, paris),
+		},
+		{
+			name:     "DTSTART in UTC",
+			schedule: "RRULE:FREQ=DAILY\nDTSTART:20240101T090000Z",
+			expected: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, 1),
+		},
+		{
+			name:     "DTSTART with a time zone",
+			schedule: "RRULE:FREQ=DAILY\nDTSTART;TZID=Asia/Tokyo:20240101T090000",
+			expected: time.Date(2024, 6, 2, 9, 0, 0, 0, tokyo),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.schedule)
+			if err != nil {
+				t.Fatalf("ParseSchedule() returned an unexpected error: %v", err)
+			}
+
+			next := InLocation(schedule, paris).Next(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+			if !next.Equal(tt.expected) {
+				t.Errorf("Schedule evaluated in Paris fires at %s, expected %s", next, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScheduleTypeOf(t *testing.T) {
+	tests := map[string]string{
+		"0 0 8 * * *": ScheduleTypeCron,
+		"@daily":      ScheduleTypeCron,
+		"DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY": ScheduleTypeRRule,
+		"RRULE:FREQ=DAILY":                           ScheduleTypeRRule,
+	}
+
+	for schedule, expected := range tests {
+		if scheduleType := ScheduleTypeOf(schedule); scheduleType != expected {
+			t.Errorf("ScheduleTypeOf() for schedule '%s' returned '%s', expected '%s'", schedule, scheduleType, expected)
+		}
+	}
+}
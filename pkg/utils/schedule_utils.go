@@ -2,19 +2,298 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
 // NextRunTime calculates the next scheduled run time for a given cron schedule string.
-// The schedule string should be in the standard cron format (6 fields: second, minute, hour, day-of-month, month, day-of-week).
+// The schedule string can be in any format accepted by ParseSchedule.
 func NextRunTime(cronSchedule string) (time.Time, error) {
-	parser := cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	schedule, err := parser.Parse(cronSchedule)
+	schedule, err := ParseSchedule(cronSchedule)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse cron schedule '%s': %w", cronSchedule, err)
+		return time.Time{}, err
 	}
 
 	return schedule.Next(time.Now()), nil
 }
+
+// NextRunTimeInLocation calculates the next scheduled run time of a cron schedule evaluated in the given time zone,
+// e.g. to follow daylight saving time. A schedule starting with CRON_TZ= keeps its own time zone.
+func NextRunTimeInLocation(cronSchedule string, loc *time.Location) (time.Time, error) {
+	schedule, err := ParseSchedule(cronSchedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return InLocation(schedule, loc).Next(time.Now()), nil
+}
+
+// InLocation returns the schedule evaluated in the given time zone, unless the schedule sets its own with CRON_TZ=
+// or with the TZID of its DTSTART. A nil location keeps the time zone of the server.
+func InLocation(schedule cron.Schedule, loc *time.Location) cron.Schedule {
+	if rruleSchedule, ok := schedule.(*RRuleSchedule); ok {
+		return rruleSchedule.inLocation(loc)
+	}
+
+	spec, ok := schedule.(*cron.SpecSchedule)
+	if !ok || loc == nil || spec.Location != time.Local {
+		return schedule
+	}
+
+	located := *spec
+	located.Location = loc
+	return &located
+}
+
+// maxMissedRunTimesScan bounds the fire times scanned by MissedRunTimes, e.g. for a
+// schedule running every second after a long downtime
+const maxMissedRunTimesScan = 100000
+
+// MissedRunTimes returns the fire times of the schedule from `from` (included) to `to` (excluded).
+// Only the latest maxRuns fire times are returned, in chronological order.
+func MissedRunTimes(schedule cron.Schedule, from time.Time, to time.Time, maxRuns int) []time.Time {
+	runTimes := []time.Time{}
+	if maxRuns <= 0 {
+		return runTimes
+	}
+
+	// Next returns fire times strictly after its argument
+	next := schedule.Next(from.Add(-time.Nanosecond))
+	for i := 0; i < maxMissedRunTimesScan && !next.IsZero() && next.Before(to); i++ {
+		runTimes = append(runTimes, next)
+		if len(runTimes) > maxRuns {
+			runTimes = runTimes[1:]
+		}
+		next = schedule.Next(next)
+	}
+	return runTimes
+}
+
+// scheduleFieldNames are the fields of a 6-field schedule, in order. 5-field schedules have no second field.
+var scheduleFieldNames = []string{"second", "minute", "hour", "day of month", "month", "day of week"}
+
+var (
+	// secondsParser parses 6-field schedules, starting with the second field
+	secondsParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	// standardParser parses standard 5-field crontab schedules
+	standardParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	// descriptorParser parses descriptors such as @daily and @every schedules
+	descriptorParser = cron.NewParser(cron.Descriptor)
+)
+
+// descriptorSchedules are the 6-field schedules descriptors are normalized to
+var descriptorSchedules = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// ScheduleParser parses schedules with ParseSchedule, e.g. for cron.WithParser
+type ScheduleParser struct{}
+
+// Parse implements cron.ScheduleParser
+func (ScheduleParser) Parse(spec string) (cron.Schedule, error) {
+	return ParseSchedule(spec)
+}
+
+// ParseSchedule parses a cron schedule string, detecting its format:
+//   - 6 fields: second, minute, hour, day-of-month, month, day-of-week
+//   - 5 fields: standard crontab, running at second 0
+//   - descriptors such as @daily or @hourly, and @every followed by a duration, e.g. @every 90s
+//   - RFC 5545 recurrence rules, see ParseRRuleSchedule
+//
+// Cron formats can be prefixed with CRON_TZ= to set the time zone of the schedule. Their H tokens
+// are resolved with an empty seed, see ResolveHashedSchedule to resolve them for a job.
+func ParseSchedule(cronSchedule string) (cron.Schedule, error) {
+	schedule, err := parseSchedule(cronSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cron schedule '%s': %w", cronSchedule, err)
+	}
+	return schedule, nil
+}
+
+// parseSchedule parses a cron schedule string with the parser of its format
+func parseSchedule(cronSchedule string) (cron.Schedule, error) {
+	if IsRRuleSchedule(cronSchedule) {
+		return ParseRRuleSchedule(cronSchedule, nil)
+	}
+
+	resolved, err := ResolveHashedSchedule(cronSchedule, "")
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, expression := splitTimeZone(resolved)
+	if strings.HasPrefix(strings.TrimSpace(expression), "@") {
+		return descriptorParser.Parse(resolved)
+	}
+
+	switch fields := strings.Fields(expression); len(fields) {
+	case len(scheduleFieldNames):
+		return secondsParser.Parse(resolved)
+	case len(scheduleFieldNames) - 1:
+		return standardParser.Parse(resolved)
+	default:
+		return nil, fmt.Errorf("expected 5 or 6 fields, found %d: %s", len(fields), strings.TrimSpace(expression))
+	}
+}
+
+// NormalizeSchedule returns the canonical form of a cron schedule string, used to store it:
+// 5-field schedules and descriptors are written as 6-field schedules, @every durations are
+// written the way time.Duration formats them, and the time zone prefix is written CRON_TZ=.
+// Recurrence rules are written with one trimmed property per line.
+func NormalizeSchedule(cronSchedule string) (string, error) {
+	if IsRRuleSchedule(cronSchedule) {
+		return normalizeRRuleSchedule(cronSchedule)
+	}
+
+	schedule, err := ParseSchedule(cronSchedule)
+	if err != nil {
+		return "", err
+	}
+
+	prefix, _, expression := splitTimeZone(cronSchedule)
+	fields := strings.Fields(expression)
+
+	var normalized string
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok {
+		normalized = "@every " + every.Delay.String()
+	} else if descriptorSchedule, ok := descriptorSchedules[fields[0]]; ok {
+		normalized = descriptorSchedule
+	} else if len(fields) == len(scheduleFieldNames)-1 {
+		normalized = "0 " + strings.Join(fields, " ")
+	} else {
+		normalized = strings.Join(fields, " ")
+	}
+
+	if prefix != "" {
+		normalized = "CRON_TZ=" + prefix[strings.Index(prefix, "=")+1:] + " " + normalized
+	}
+	return normalized, nil
+}
+
+// splitTimeZone splits the CRON_TZ= or TZ= prefix of a cron schedule string from its expression.
+// The offset is where the expression starts in the schedule string.
+func splitTimeZone(cronSchedule string) (prefix string, offset int, expression string) {
+	if !strings.HasPrefix(cronSchedule, "CRON_TZ=") && !strings.HasPrefix(cronSchedule, "TZ=") {
+		return "", 0, cronSchedule
+	}
+	end := strings.Index(cronSchedule, " ")
+	if end < 0 {
+		return cronSchedule, len(cronSchedule), ""
+	}
+	return cronSchedule[:end], end, cronSchedule[end:]
+}
+
+// ScheduleError is a schedule parse error located in the expression
+type ScheduleError struct {
+	Message  string // Error returned by the parser
+	Field    string // Name of the invalid field, empty when the error is not about a single field
+	Position int    // Byte offset of the invalid field in the expression, 0 when not about a single field
+}
+
+func (e *ScheduleError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("invalid %s field at position %d: %s", e.Field, e.Position, e.Message)
+}
+
+// ValidateSchedule parses a cron schedule string like ParseSchedule, returning a *ScheduleError
+// locating the invalid field on failure
+func ValidateSchedule(cronSchedule string) (cron.Schedule, error) {
+	schedule, err := parseSchedule(cronSchedule)
+	if err == nil {
+		return schedule, nil
+	}
+
+	scheduleErr := &ScheduleError{Message: err.Error()}
+	if IsRRuleSchedule(cronSchedule) {
+		return nil, scheduleErr
+	}
+
+	// The fields are located after the time zone prefix
+	_, offset, expression := splitTimeZone(cronSchedule)
+	fields := strings.Fields(expression)
+
+	var parser cron.Parser
+	var fieldNames []string
+	switch len(fields) {
+	case len(scheduleFieldNames):
+		parser, fieldNames = secondsParser, scheduleFieldNames
+	case len(scheduleFieldNames) - 1:
+		parser, fieldNames = standardParser, scheduleFieldNames[1:]
+	default:
+		// Descriptors and wrong field counts are not about a single field
+		return nil, scheduleErr
+	}
+	if strings.HasPrefix(fields[0], "@") {
+		return nil, scheduleErr
+	}
+
+	// Parse each field alone to find the invalid one
+	for i, field := range fields {
+		isolated := make([]string, len(fields))
+		for j := range isolated {
+			isolated[j] = "*"
+		}
+		isolated[i] = field
+		isolatedSchedule, fieldErr := ResolveHashedSchedule(strings.Join(isolated, " "), "")
+		if fieldErr == nil {
+			_, fieldErr = parser.Parse(isolatedSchedule)
+		}
+		if fieldErr != nil {
+			scheduleErr.Message = fieldErr.Error()
+			scheduleErr.Field = fieldNames[i]
+			scheduleErr.Position = offset + fieldPosition(expression, i)
+			break
+		}
+	}
+	return nil, scheduleErr
+}
+
+// fieldPosition returns the byte offset of the field at the given index in a space-separated expression
+func fieldPosition(expression string, index int) int {
+	inField := false
+	count := -1
+	for i, c := range expression {
+		isSpace := c == ' ' || c == '\t'
+		if !isSpace && !inField {
+			count++
+			if count == index {
+				return i
+			}
+		}
+		inField = !isSpace
+	}
+	return 0
+}
+
+// NextRunTimes returns the next count run times after from of a cron schedule evaluated in the given time zone.
+// A nil location uses the time zone of the server. Parse errors are returned as *ScheduleError.
+func NextRunTimes(cronSchedule string, loc *time.Location, from time.Time, count int) ([]time.Time, error) {
+	schedule, err := ValidateSchedule(cronSchedule)
+	if err != nil {
+		return nil, err
+	}
+	schedule = InLocation(schedule, loc)
+
+	runTimes := []time.Time{}
+	next := from
+	for i := 0; i < count; i++ {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		runTimes = append(runTimes, next)
+	}
+	return runTimes, nil
+}
@@ -31,8 +31,26 @@ func TestNextRunTime(t *testing.T) {
 			delta:        1 * time.Minute, // Allow 1 minute difference for minute-level precision
 		},
 		{
-			name:         "Invalid schedule - Too few fields",
+			name:         "Valid schedule - Every minute, 5 fields",
 			cronSchedule: "* * * * *",
+			expectError:  false,
+			delta:        1 * time.Minute,
+		},
+		{
+			name:         "Valid schedule - Hourly descriptor",
+			cronSchedule: "@hourly",
+			expectError:  false,
+			delta:        1 * time.Hour,
+		},
+		{
+			name:         "Valid schedule - Every 90 seconds",
+			cronSchedule: "@every 90s",
+			expectError:  false,
+			delta:        90 * time.Second,
+		},
+		{
+			name:         "Invalid schedule - Too few fields",
+			cronSchedule: "* * * *",
 			expectError:  true,
 		},
 		{
@@ -80,3 +98,290 @@ func TestNextRunTime(t *testing.T) {
 		})
 	}
 }
+
+func TestMissedRunTimes(t *testing.T) {
+	from := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		cronSchedule string
+		to           time.Time
+		maxRuns      int
+		expected     []time.Time
+	}{
+		{
+			name:         "No missed run",
+			cronSchedule: "0 0 * * * *", // Every hour
+			to:           from,
+			maxRuns:      5,
+			expected:     []time.Time{},
+		},
+		{
+			name:         "All missed runs",
+			cronSchedule: "0 0 * * * *",
+			to:           from.Add(2*time.Hour + 30*time.Minute),
+			maxRuns:      5,
+			expected:     []time.Time{from, from.Add(time.Hour), from.Add(2 * time.Hour)},
+		},
+		{
+			name:         "Latest missed runs only",
+			cronSchedule: "0 0 * * * *",
+			to:           from.Add(5*time.Hour + 30*time.Minute),
+			maxRuns:      2,
+			expected:     []time.Time{from.Add(4 * time.Hour), from.Add(5 * time.Hour)},
+		},
+		{
+			name:         "Catch-up disabled",
+			cronSchedule: "0 0 * * * *",
+			to:           from.Add(5 * time.Hour),
+			maxRuns:      0,
+			expected:     []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.cronSchedule)
+			if err != nil {
+				t.Fatalf("ParseSchedule() for schedule '%s' returned an unexpected error: %v", tt.cronSchedule, err)
+			}
+
+			runTimes := MissedRunTimes(schedule, from, tt.to, tt.maxRuns)
+			if len(runTimes) != len(tt.expected) {
+				t.Fatalf("MissedRunTimes() returned %v, expected %v", runTimes, tt.expected)
+			}
+			for i := range runTimes {
+				if !runTimes[i].Equal(tt.expected[i]) {
+					t.Errorf("MissedRunTimes()[%d] = %s, expected %s", i, runTimes[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNextRunTimeInLocation(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("Time zone database not available: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		cronSchedule string
+		location     *time.Location
+		expectedHour int
+		expectedIn   *time.Location
+		expectError  bool
+	}{
+		{
+			name:         "Every day at 08:00 in Paris",
+			cronSchedule: "0 0 8 * * *",
+			location:     paris,
+			expectedHour: 8,
+			expectedIn:   paris,
+		},
+		{
+			name:         "Time zone set in the schedule",
+			cronSchedule: "CRON_TZ=UTC 0 0 8 * * *",
+			location:     paris,
+			expectedHour: 8,
+			expectedIn:   time.UTC,
+		},
+		{
+			name:         "Invalid schedule",
+			cronSchedule: "invalid cron string",
+			location:     paris,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextTime, err := NextRunTimeInLocation(tt.cronSchedule, tt.location)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("NextRunTimeInLocation() expected an error for schedule '%s', but got none", tt.cronSchedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextRunTimeInLocation() for schedule '%s' returned an unexpected error: %v", tt.cronSchedule, err)
+			}
+
+			localTime := nextTime.In(tt.expectedIn)
+			if localTime.Hour() != tt.expectedHour || localTime.Minute() != 0 {
+				t.Errorf("NextRunTimeInLocation() for schedule '%s' returned %s, expected %02d:00", tt.cronSchedule, nextTime, tt.expectedHour)
+			}
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	tests := []struct {
+		name             string
+		cronSchedule     string
+		expectError      bool
+		expectedField    string
+		expectedPosition int
+	}{
+		{
+			name:         "Valid schedule",
+			cronSchedule: "0 */5 * * * *",
+		},
+		{
+			name:             "Invalid hour",
+			cronSchedule:     "0 0 25 * * *",
+			expectError:      true,
+			expectedField:    "hour",
+			expectedPosition: 4,
+		},
+		{
+			name:             "Invalid day of week after a time zone",
+			cronSchedule:     "CRON_TZ=UTC 0 0 8 * * FOO",
+			expectError:      true,
+			expectedField:    "day of week",
+			expectedPosition: 22,
+		},
+		{
+			name:             "Invalid minute of a 5-field schedule",
+			cronSchedule:     "99 8 * * *",
+			expectError:      true,
+			expectedField:    "minute",
+			expectedPosition: 0,
+		},
+		{
+			name:         "Too few fields",
+			cronSchedule: "0 8 * *",
+			expectError:  true,
+		},
+		{
+			name:         "Unknown descriptor",
+			cronSchedule: "@sometimes",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateSchedule(tt.cronSchedule)
+
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("ValidateSchedule() for schedule '%s' returned an unexpected error: %v", tt.cronSchedule, err)
+				}
+				return
+			}
+			scheduleErr, ok := err.(*ScheduleError)
+			if !ok {
+				t.Fatalf("ValidateSchedule() for schedule '%s' returned %v, expected a *ScheduleError", tt.cronSchedule, err)
+			}
+			if scheduleErr.Field != tt.expectedField || scheduleErr.Position != tt.expectedPosition {
+				t.Errorf("ValidateSchedule() for schedule '%s' located the error in field '%s' at %d, expected '%s' at %d",
+					tt.cronSchedule, scheduleErr.Field, scheduleErr.Position, tt.expectedField, tt.expectedPosition)
+			}
+		})
+	}
+}
+
+func TestNextRunTimes(t *testing.T) {
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	runTimes, err := NextRunTimes("0 0 * * * *", time.UTC, from, 3)
+	if err != nil {
+		t.Fatalf("NextRunTimes() returned an unexpected error: %v", err)
+	}
+
+	expected := []time.Time{
+		time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+	}
+	if len(runTimes) != len(expected) {
+		t.Fatalf("NextRunTimes() returned %d run times, expected %d", len(runTimes), len(expected))
+	}
+	for i := range expected {
+		if !runTimes[i].Equal(expected[i]) {
+			t.Errorf("NextRunTimes()[%d] = %s, expected %s", i, runTimes[i], expected[i])
+		}
+	}
+
+	if _, err := NextRunTimes("invalid cron string", time.UTC, from, 3); err == nil {
+		t.Errorf("NextRunTimes() expected an error for an invalid schedule, but got none")
+	}
+}
+
+func TestNormalizeSchedule(t *testing.T) {
+	tests := []struct {
+		name         string
+		cronSchedule string
+		expected     string
+		expectError  bool
+	}{
+		{
+			name:         "6 fields",
+			cronSchedule: "0  */5 * * * *",
+			expected:     "0 */5 * * * *",
+		},
+		{
+			name:         "5 fields",
+			cronSchedule: "30 8 * * 1-5",
+			expected:     "0 30 8 * * 1-5",
+		},
+		{
+			name:         "Descriptor",
+			cronSchedule: "@daily",
+			expected:     "0 0 0 * * *",
+		},
+		{
+			name:         "Every",
+			cronSchedule: "@every 90s",
+			expected:     "@every 1m30s",
+		},
+		{
+			name:         "Time zone",
+			cronSchedule: "TZ=UTC 30 8 * * *",
+			expected:     "CRON_TZ=UTC 0 30 8 * * *",
+		},
+		{
+			name:         "Recurrence rule",
+			cronSchedule: "  DTSTART:20240101T090000Z \n\n RRULE:FREQ=DAILY\n",
+			expected:     "DTSTART:20240101T090000Z\nRRULE:FREQ=DAILY",
+		},
+		{
+			name:         "Invalid schedule",
+			cronSchedule: "invalid cron string",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := NormalizeSchedule(tt.cronSchedule)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("NormalizeSchedule() expected an error for schedule '%s', but got none", tt.cronSchedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeSchedule() for schedule '%s' returned an unexpected error: %v", tt.cronSchedule, err)
+			}
+			if normalized != tt.expected {
+				t.Errorf("NormalizeSchedule() for schedule '%s' returned '%s', expected '%s'", tt.cronSchedule, normalized, tt.expected)
+			}
+
+			// The normalized schedule fires at the same times
+			from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+			original, _ := ParseSchedule(tt.cronSchedule)
+			parsed, err := ParseSchedule(normalized)
+			if err != nil {
+				t.Fatalf("ParseSchedule() for normalized schedule '%s' returned an unexpected error: %v", normalized, err)
+			}
+			if !parsed.Next(from).Equal(original.Next(from)) {
+				t.Errorf("Normalized schedule '%s' fires at %s, expected %s", normalized, parsed.Next(from), original.Next(from))
+			}
+		})
+	}
+}